@@ -0,0 +1,170 @@
+// Package deletionpreview implements the command previewing the deletion of a
+// tenant cluster using the deletion preview endpoint of a running operator.
+package deletionpreview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/cluster-operator/v3/server/endpoint/deletionpreview"
+	servicedeletionpreview "github.com/giantswarm/cluster-operator/v3/service/deletionpreview"
+)
+
+const (
+	flagAddress   = "address"
+	flagClusterID = "cluster-id"
+	flagOutput    = "output"
+
+	outputJSON  = "json"
+	outputTable = "table"
+)
+
+type Command struct {
+	cobraCommand *cobra.Command
+
+	address   string
+	clusterID string
+	output    string
+}
+
+func New() *Command {
+	c := &Command{}
+
+	c.cobraCommand = &cobra.Command{
+		Use:   "deletionpreview",
+		Short: "Preview the objects the deletion of a tenant cluster would delete.",
+		Long:  "Preview the objects the deletion of a tenant cluster would delete, in the order they would be deleted, using the deletion preview endpoint of a running operator. Nothing is changed.",
+		RunE:  c.Execute,
+	}
+
+	c.cobraCommand.Flags().StringVar(&c.address, flagAddress, "http://127.0.0.1:8000", "Address of the running operator.")
+	c.cobraCommand.Flags().StringVar(&c.clusterID, flagClusterID, "", "ID of the tenant cluster to preview the deletion for.")
+	c.cobraCommand.Flags().StringVar(&c.output, flagOutput, outputTable, "Output format. One of json, table.")
+
+	return c
+}
+
+func (c *Command) CobraCommand() *cobra.Command {
+	return c.cobraCommand
+}
+
+func (c *Command) Execute(cmd *cobra.Command, args []string) error {
+	if c.clusterID == "" {
+		return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagClusterID)
+	}
+	if c.output != outputJSON && c.output != outputTable {
+		return microerror.Maskf(invalidFlagError, "--%s must be one of %s, %s", flagOutput, outputJSON, outputTable)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	p, err := fetch(ctx, c.address, c.clusterID)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if c.output == outputJSON {
+		e := json.NewEncoder(cmd.OutOrStdout())
+		e.SetIndent("", "  ")
+
+		err = e.Encode(p)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
+	err = render(cmd.OutOrStdout(), p)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// fetch requests the deletion preview of the given tenant cluster from the
+// operator listening on the given address.
+func fetch(ctx context.Context, address, clusterID string) (servicedeletionpreview.Preview, error) {
+	u, err := url.Parse(strings.TrimSuffix(address, "/") + deletionpreview.Path)
+	if err != nil {
+		return servicedeletionpreview.Preview{}, microerror.Maskf(invalidFlagError, "--%s: %s", flagAddress, err)
+	}
+	u.RawQuery = url.Values{deletionpreview.ClusterIDParam: []string{clusterID}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, deletionpreview.Method, u.String(), nil)
+	if err != nil {
+		return servicedeletionpreview.Preview{}, microerror.Mask(err)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return servicedeletionpreview.Preview{}, microerror.Mask(err)
+	}
+	defer res.Body.Close()
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return servicedeletionpreview.Preview{}, microerror.Mask(err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return servicedeletionpreview.Preview{}, microerror.Maskf(executionFailedError, "%s: %s", res.Status, strings.TrimSpace(string(b)))
+	}
+
+	var p servicedeletionpreview.Preview
+	err = json.Unmarshal(b, &p)
+	if err != nil {
+		return servicedeletionpreview.Preview{}, microerror.Mask(err)
+	}
+
+	return p, nil
+}
+
+// render writes the given deletion preview as table.
+func render(w io.Writer, p servicedeletionpreview.Preview) error {
+	if p.DeletionProtected {
+		_, err := fmt.Fprintf(w, "Cluster %s is protected from deletion. Nothing is deleted until the protection is removed.\n\n", p.ClusterID)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	t := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+
+	_, err := fmt.Fprintln(t, "#\tPHASE\tCLUSTER\tKIND\tNAMESPACE\tNAME\tRESOURCE")
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for i, o := range p.Objects {
+		cluster := "management"
+		if o.Tenant {
+			cluster = "tenant"
+		}
+
+		_, err = fmt.Fprintf(t, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, o.Phase, cluster, o.Kind, o.Namespace, o.Name, o.Resource)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	err = t.Flush()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package deletionpreview
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	servicedeletionpreview "github.com/giantswarm/cluster-operator/v3/service/deletionpreview"
+)
+
+func Test_fetch_render(t *testing.T) {
+	testCases := []struct {
+		name           string
+		status         int
+		preview        servicedeletionpreview.Preview
+		expectedOutput string
+		errorMatcher   func(error) bool
+	}{
+		{
+			name:   "case 0: objects are rendered in order",
+			status: http.StatusOK,
+			preview: servicedeletionpreview.Preview{
+				ClusterID: "al9qy",
+				Objects: []servicedeletionpreview.Object{
+					{Kind: "Service", Name: "ingress", Namespace: "kube-system", Phase: "Apps", Resource: "tenantcleanup", Tenant: true},
+					{Kind: "Namespace", Name: "al9qy", Phase: "Management", Resource: "cpnamespace"},
+				},
+			},
+			expectedOutput: `#  PHASE       CLUSTER     KIND       NAMESPACE    NAME     RESOURCE
+1  Apps        tenant      Service    kube-system  ingress  tenantcleanup
+2  Management  management  Namespace               al9qy    cpnamespace
+`,
+		},
+		{
+			name:   "case 1: deletion protection is reported",
+			status: http.StatusOK,
+			preview: servicedeletionpreview.Preview{
+				ClusterID:         "al9qy",
+				DeletionProtected: true,
+			},
+			expectedOutput: `Cluster al9qy is protected from deletion. Nothing is deleted until the protection is removed.
+
+#  PHASE  CLUSTER  KIND  NAMESPACE  NAME  RESOURCE
+`,
+		},
+		{
+			name:         "case 2: unknown cluster",
+			status:       http.StatusNotFound,
+			errorMatcher: IsExecutionFailed,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("cluster_id") != "al9qy" {
+					t.Fatalf("expected cluster ID %#q, got %#q", "al9qy", r.URL.Query().Get("cluster_id"))
+				}
+
+				w.WriteHeader(tc.status)
+				_ = json.NewEncoder(w).Encode(tc.preview)
+			}))
+			defer s.Close()
+
+			p, err := fetch(context.Background(), s.URL, "al9qy")
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if tc.errorMatcher != nil {
+				return
+			}
+
+			var b bytes.Buffer
+			err = render(&b, p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if b.String() != tc.expectedOutput {
+				t.Fatalf("output == %q, want %q", b.String(), tc.expectedOutput)
+			}
+		})
+	}
+}
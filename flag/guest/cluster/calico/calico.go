@@ -1,9 +1,14 @@
 package calico
 
+import (
+	"github.com/giantswarm/cluster-operator/v3/flag/guest/cluster/calico/pool"
+)
+
 // Calico is a data structure to hold guest cluster Calico specific
 // configuration flags.
 type Calico struct {
 	CIDR   string
 	MTU    string
+	Pool   pool.Pool
 	Subnet string
 }
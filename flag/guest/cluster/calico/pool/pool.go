@@ -0,0 +1,8 @@
+package pool
+
+// Pool is a data structure to hold guest cluster pod CIDR pool specific
+// configuration flags.
+type Pool struct {
+	CIDR         string
+	PrefixLength string
+}
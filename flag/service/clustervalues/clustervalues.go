@@ -0,0 +1,7 @@
+package clustervalues
+
+// ClusterValues is a data structure to hold configuration flags of the
+// cluster values config map apps of tenant clusters receive.
+type ClusterValues struct {
+	Template string
+}
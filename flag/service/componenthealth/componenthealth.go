@@ -0,0 +1,7 @@
+package componenthealth
+
+// ComponentHealth is a data structure to hold the configuration flags of the
+// health probes run against tenant cluster components.
+type ComponentHealth struct {
+	Probes string
+}
@@ -0,0 +1,8 @@
+package degraded
+
+// Degraded is a data structure to hold the configuration flags deciding when
+// tenant clusters are considered degraded.
+type Degraded struct {
+	CreationThreshold string
+	UpdateThreshold   string
+}
@@ -0,0 +1,9 @@
+package deletion
+
+// Deletion is a data structure to hold the configuration flags of the tenant
+// cluster deletion.
+type Deletion struct {
+	BlockerMaxAge        string
+	PhaseTimeouts        string
+	TenantCleanupTimeout string
+}
@@ -0,0 +1,8 @@
+package pullsecret
+
+// PullSecret is a data structure to hold the configuration flags of the image
+// pull secret distributed to tenant cluster apps.
+type PullSecret struct {
+	Name      string
+	Namespace string
+}
@@ -1,7 +1,11 @@
 package registry
 
+import "github.com/giantswarm/cluster-operator/v3/flag/service/image/registry/pullsecret"
+
 // Registry is a data structure to hold docker registry specific configuration
 // flags.
 type Registry struct {
-	Domain string
+	Domain     string
+	Mirrors    string
+	PullSecret pullsecret.PullSecret
 }
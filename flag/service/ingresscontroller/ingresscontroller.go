@@ -0,0 +1,7 @@
+package ingresscontroller
+
+// IngressController is a data structure to hold configuration flags of the
+// ingress controller values config map apps of tenant clusters receive.
+type IngressController struct {
+	ProviderDefaults string
+}
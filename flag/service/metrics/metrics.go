@@ -0,0 +1,7 @@
+package metrics
+
+// Metrics is a data structure to hold the configuration flags of the metrics
+// exported by the operator.
+type Metrics struct {
+	MaxClusterIDs string
+}
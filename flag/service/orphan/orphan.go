@@ -0,0 +1,9 @@
+package orphan
+
+// Orphan is a data structure to hold the configuration flags of the sweeper
+// looking for objects whose tenant cluster does not exist anymore.
+type Orphan struct {
+	Enforcing   string
+	GracePeriod string
+	Interval    string
+}
@@ -3,17 +3,31 @@ package service
 import (
 	"github.com/giantswarm/operatorkit/v4/pkg/flag/service/kubernetes"
 
+	"github.com/giantswarm/cluster-operator/v3/flag/service/clustervalues"
+	"github.com/giantswarm/cluster-operator/v3/flag/service/componenthealth"
+	"github.com/giantswarm/cluster-operator/v3/flag/service/degraded"
+	"github.com/giantswarm/cluster-operator/v3/flag/service/deletion"
 	"github.com/giantswarm/cluster-operator/v3/flag/service/image"
+	"github.com/giantswarm/cluster-operator/v3/flag/service/ingresscontroller"
 	"github.com/giantswarm/cluster-operator/v3/flag/service/kubeconfig"
+	"github.com/giantswarm/cluster-operator/v3/flag/service/metrics"
+	"github.com/giantswarm/cluster-operator/v3/flag/service/orphan"
 	"github.com/giantswarm/cluster-operator/v3/flag/service/provider"
 	"github.com/giantswarm/cluster-operator/v3/flag/service/release"
 )
 
 // Service is an intermediate data structure for command line configuration flags.
 type Service struct {
-	Image      image.Image
-	KubeConfig kubeconfig.KubeConfig
-	Kubernetes kubernetes.Kubernetes
-	Provider   provider.Provider
-	Release    release.Release
+	ClusterValues     clustervalues.ClusterValues
+	ComponentHealth   componenthealth.ComponentHealth
+	Degraded          degraded.Degraded
+	Deletion          deletion.Deletion
+	Image             image.Image
+	IngressController ingresscontroller.IngressController
+	KubeConfig        kubeconfig.KubeConfig
+	Kubernetes        kubernetes.Kubernetes
+	Metrics           metrics.Metrics
+	Orphan            orphan.Orphan
+	Provider          provider.Provider
+	Release           release.Release
 }
@@ -17,9 +17,11 @@ require (
 	github.com/giantswarm/operatorkit/v4 v4.0.0
 	github.com/giantswarm/resource/v2 v2.3.0
 	github.com/giantswarm/tenantcluster/v3 v3.0.0
+	github.com/go-kit/kit v0.10.0
 	github.com/patrickmn/go-cache v2.1.0+incompatible
 	github.com/prometheus/client_golang v1.9.0
 	github.com/spf13/afero v1.5.1
+	github.com/spf13/cobra v1.0.0
 	github.com/spf13/viper v1.7.1
 	gopkg.in/yaml.v2 v2.4.0
 	k8s.io/api v0.18.9
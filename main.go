@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"time"
 
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/microkit/command"
@@ -9,6 +10,7 @@ import (
 	"github.com/giantswarm/micrologger"
 	"github.com/spf13/viper"
 
+	"github.com/giantswarm/cluster-operator/v3/command/deletionpreview"
 	"github.com/giantswarm/cluster-operator/v3/flag"
 	"github.com/giantswarm/cluster-operator/v3/pkg/project"
 	"github.com/giantswarm/cluster-operator/v3/server"
@@ -108,14 +110,29 @@ func mainE() error {
 
 	daemonCommand := newCommand.DaemonCommand().CobraCommand()
 
-	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Calico.CIDR, "", "Prefix length for the CIDR block used by Calico.")
-	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Calico.Subnet, "", "Network address for the CIDR block used by Calico.")
-	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Kubernetes.API.ClusterIPRange, "", "CIDR Range for Pods in cluster.")
+	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Calico.CIDR, "", "Prefix length for the CIDR block used by Calico. Comma separated per IP family for dual-stack clusters.")
+	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Calico.Pool.CIDR, "", "CIDR block pod CIDRs are allocated from for clusters not specifying one. Allocation is disabled when empty.")
+	daemonCommand.PersistentFlags().Int(f.Guest.Cluster.Calico.Pool.PrefixLength, 16, "Prefix length of the pod CIDRs allocated from the pool.")
+	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Calico.Subnet, "", "Network address for the CIDR block used by Calico. Comma separated per IP family for dual-stack clusters.")
+	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Kubernetes.API.ClusterIPRange, "", "CIDR Range for Pods in cluster. Comma separated per IP family for dual-stack clusters.")
 	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Kubernetes.ClusterDomain, "cluster.local", "Internal Kubernetes domain.")
 	daemonCommand.PersistentFlags().String(f.Guest.Cluster.Vault.Certificate.TTL, "", "Vault certificate TTL.")
 
+	daemonCommand.PersistentFlags().String(f.Service.ClusterValues.Template, "", "Go template the cluster values config map of tenant clusters is rendered from. The built-in template is used when empty.")
+	daemonCommand.PersistentFlags().String(f.Service.ComponentHealth.Probes, "", "Health probes run against tenant cluster components feeding the ComponentsHealthy condition.")
+	daemonCommand.PersistentFlags().Duration(f.Service.Degraded.CreationThreshold, 30*time.Minute, "Duration after which a tenant cluster still being created is considered degraded.")
+	daemonCommand.PersistentFlags().Duration(f.Service.Degraded.UpdateThreshold, 2*time.Hour, "Duration after which a tenant cluster still being updated is considered degraded.")
+	daemonCommand.PersistentFlags().Duration(f.Service.Deletion.BlockerMaxAge, time.Hour, "Duration after which objects blocking the deletion of a tenant cluster are reported using Warning events.")
+	daemonCommand.PersistentFlags().String(f.Service.Deletion.PhaseTimeouts, "", "YAML map of tenant cluster deletion phases to the duration after which they are reported as overrun. Phases not given time out after 30m.")
+	daemonCommand.PersistentFlags().Duration(f.Service.Deletion.TenantCleanupTimeout, 10*time.Minute, "Duration the deletion of a tenant cluster waits for its load balancers and volumes to be deleted after the start of the Apps deletion phase before deleting its infrastructure.")
 	daemonCommand.PersistentFlags().String(f.Service.Image.Registry.Domain, "quay.io", "Image registry.")
+	daemonCommand.PersistentFlags().String(f.Service.Image.Registry.Mirrors, "", "Comma separated list of image registry mirrors passed to tenant cluster apps.")
+	daemonCommand.PersistentFlags().String(f.Service.Image.Registry.PullSecret.Name, "", "Name of the dockerconfigjson secret distributed to tenant cluster apps for pulling images. No pull secret is distributed when empty.")
+	daemonCommand.PersistentFlags().String(f.Service.Image.Registry.PullSecret.Namespace, "giantswarm", "Namespace of the dockerconfigjson secret distributed to tenant cluster apps.")
+	daemonCommand.PersistentFlags().Bool(f.Service.IngressController.ProviderDefaults, true, "Whether to add provider specific service, load balancer and replica defaults to the ingress controller values of tenant clusters. Only the proxy protocol setting is defaulted when disabled.")
 
+	daemonCommand.PersistentFlags().String(f.Service.KubeConfig.Profiles, "", "Additional kubeconfig profiles issued for tenant clusters.")
+	daemonCommand.PersistentFlags().Bool(f.Service.KubeConfig.Secret.CAPI, false, "Whether to additionally publish kubeconfig secrets in the Cluster API format.")
 	daemonCommand.PersistentFlags().String(f.Service.KubeConfig.Secret.Namespace, "giantswarm", "The namespace where kubeconfig secrets are located.")
 	daemonCommand.PersistentFlags().String(f.Service.Kubernetes.Address, "", "Address used to connect to Kubernetes. When empty in-cluster config is created.")
 	daemonCommand.PersistentFlags().Bool(f.Service.Kubernetes.InCluster, true, "Whether to use the in-cluster config to authenticate with Kubernetes.")
@@ -123,11 +140,19 @@ func mainE() error {
 	daemonCommand.PersistentFlags().String(f.Service.Kubernetes.TLS.CrtFile, "", "Certificate file path to use to authenticate with Kubernetes.")
 	daemonCommand.PersistentFlags().String(f.Service.Kubernetes.TLS.KeyFile, "", "Key file path to use to authenticate with Kubernetes.")
 
+	daemonCommand.PersistentFlags().Int(f.Service.Metrics.MaxClusterIDs, 1000, "Maximum number of clusters per cluster reconciliation metrics are exported for. Per cluster reconciliation metrics are disabled when 0.")
+
+	daemonCommand.PersistentFlags().Bool(f.Service.Orphan.Enforcing, false, "Whether to delete objects whose tenant cluster does not exist anymore. Orphaned objects are only reported when disabled.")
+	daemonCommand.PersistentFlags().Duration(f.Service.Orphan.GracePeriod, 24*time.Hour, "Duration an object has to be orphaned before it is deleted in enforcing mode.")
+	daemonCommand.PersistentFlags().Duration(f.Service.Orphan.Interval, 10*time.Minute, "Duration between two sweeps for orphaned objects.")
+
 	daemonCommand.PersistentFlags().String(f.Service.Provider.Kind, "", "Provider of the installation. One of aws, azure, kvm.")
 
 	daemonCommand.PersistentFlags().String(f.Service.Release.App.Config.Default, "", "Default properties for app.")
 	daemonCommand.PersistentFlags().String(f.Service.Release.App.Config.Override, "", "Overriding properties for app.")
 
+	newCommand.CobraCommand().AddCommand(deletionpreview.New().CobraCommand())
+
 	err = newCommand.CobraCommand().Execute()
 	if err != nil {
 		return microerror.Mask(err)
@@ -6,6 +6,22 @@ const (
 	// ChartOperator is used to filter annotations.
 	ChartOperator = "chart-operator.giantswarm.io"
 
+	// ClusterIPRange is the name of the annotation on the infrastructure CR of a
+	// tenant cluster configuring its comma separated service CIDRs. The
+	// installation default is used when the annotation is not set.
+	ClusterIPRange = "cluster-operator.giantswarm.io/cluster-ip-range"
+
+	// ComponentHealth is the name of the annotation on the Cluster CR holding
+	// the JSON encoded results of the health probes of tenant cluster
+	// components.
+	ComponentHealth = "cluster-operator.giantswarm.io/component-health"
+
+	// Conditions is the name of the annotation on the Cluster CR holding the
+	// JSON encoded conditions cluster-operator observes for the tenant cluster.
+	// The conditions of the Cluster CR status are a history of transitions, which
+	// is why they cannot hold observations like these.
+	Conditions = "cluster-operator.giantswarm.io/conditions"
+
 	// CordonReason is the name of the annotation that indicates
 	// the reason of why chart-operator should not apply any update on this chart CR.
 	CordonReason = "chart-operator.giantswarm.io/cordon-reason"
@@ -18,10 +34,84 @@ const (
 	// the custom resource should be deleted without deleting the Helm release.
 	DeleteCustomResourceOnly = "chart-operator.giantswarm.io/delete-custom-resource-only"
 
+	// DeletionBlockers is the name of the annotation on the Cluster CR holding
+	// the JSON encoded list of objects which still block the deletion of the
+	// tenant cluster.
+	DeletionBlockers = "cluster-operator.giantswarm.io/deletion-blockers"
+
+	// DeletionPhase is the name of the annotation on the Cluster CR holding the
+	// JSON encoded deletion phase the tenant cluster is currently in.
+	DeletionPhase = "cluster-operator.giantswarm.io/deletion-phase"
+
+	// DeletionProtection is the name of the annotation on the Cluster CR
+	// protecting the tenant cluster from being deleted. As long as it is set to
+	// a value other than false, the deletion of the Cluster CR does not delete
+	// anything.
+	DeletionProtection = "cluster-operator.giantswarm.io/deletion-protection"
+
 	// ForceHelmUpgrade is the name of the annotation that controls whether force
 	// is used when upgrading the Helm release.
 	ForceHelmUpgrade = "chart-operator.giantswarm.io/force-helm-upgrade"
 
+	// HTTPProxy is the name of the annotation on the infrastructure CR or the
+	// Cluster CR of a tenant cluster configuring the HTTP proxy managed apps
+	// have to use.
+	HTTPProxy = "cluster-operator.giantswarm.io/http-proxy"
+
+	// HTTPSProxy is the name of the annotation on the infrastructure CR or the
+	// Cluster CR of a tenant cluster configuring the HTTPS proxy managed apps
+	// have to use.
+	HTTPSProxy = "cluster-operator.giantswarm.io/https-proxy"
+
+	// IngressControllerUserValuesVersion is the name of the annotation on the
+	// Cluster CR holding the resource version of the ingress controller user
+	// values config map of the tenant cluster. It is maintained so that changes
+	// of the user values cause the Cluster CR to be reconciled.
+	IngressControllerUserValuesVersion = "cluster-operator.giantswarm.io/ingress-controller-user-values-version"
+
+	// KubeConfigCertNotAfter is the name of the annotation holding the expiry
+	// date of the certificate embedded in a kubeconfig secret, formatted
+	// according to RFC 3339.
+	KubeConfigCertNotAfter = "cluster-operator.giantswarm.io/kubeconfig-cert-not-after"
+
+	// KubeConfigCertSerial is the name of the annotation holding the serial
+	// number of the certificate embedded in a kubeconfig secret.
+	KubeConfigCertSerial = "cluster-operator.giantswarm.io/kubeconfig-cert-serial"
+
+	// KubernetesVersion is the name of the annotation on the Cluster CR holding
+	// the JSON encoded Kubernetes versions reported by the tenant API server and
+	// the kubelets of the tenant cluster, together with their skew against the
+	// Kubernetes version of the release.
+	KubernetesVersion = "cluster-operator.giantswarm.io/kubernetes-version"
+
+	// NodePools is the name of the annotation on the Cluster CR holding the
+	// number of node pools of the tenant cluster. It is maintained by the
+	// MachineDeployment controller so that node pool changes cause the Cluster
+	// CR to be reconciled.
+	NodePools = "cluster-operator.giantswarm.io/node-pools"
+
+	// NoProxy is the name of the annotation on the infrastructure CR or the
+	// Cluster CR of a tenant cluster configuring a comma separated list of
+	// additional destinations managed apps reach without proxy. The tenant
+	// cluster's own networks and domains are never proxied.
+	NoProxy = "cluster-operator.giantswarm.io/no-proxy"
+
+	// PodCIDRAllocation is the name of the annotation on the infrastructure CR
+	// of a tenant cluster holding the pod CIDR allocated from the installation
+	// pool.
+	PodCIDRAllocation = "cluster-operator.giantswarm.io/pod-cidr-allocation"
+
+	// ReleaseResourceVersion is the name of the annotation on the Cluster CR
+	// holding the resource version of the Release CR of the tenant cluster. It
+	// is maintained so that changes of the Release CR cause the Cluster CR to
+	// be reconciled.
+	ReleaseResourceVersion = "cluster-operator.giantswarm.io/release-resource-version"
+
+	// UpgradeProgress is the name of the annotation on the Cluster CR holding
+	// the JSON encoded progress of the nodes of the tenant cluster towards the
+	// desired version, per control plane and node pool.
+	UpgradeProgress = "cluster-operator.giantswarm.io/upgrade-progress"
+
 	// Notes is for informational messages for resources generated by the operator.
 	Notes = "giantswarm.io/notes"
 )
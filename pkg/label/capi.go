@@ -0,0 +1,7 @@
+package label
+
+const (
+	// CAPICluster is the Cluster API label denoting which Cluster CR the
+	// corresponding resource belongs to.
+	CAPICluster = "cluster.x-k8s.io/cluster-name"
+)
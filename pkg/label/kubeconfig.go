@@ -0,0 +1,7 @@
+package label
+
+const (
+	// KubeConfigProfile is the name of the kubeconfig profile a kubeconfig
+	// secret or tenant cluster RBAC resource is managed for.
+	KubeConfigProfile = "cluster-operator.giantswarm.io/kubeconfig-profile"
+)
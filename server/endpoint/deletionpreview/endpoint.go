@@ -0,0 +1,104 @@
+package deletionpreview
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	kitendpoint "github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+
+	"github.com/giantswarm/cluster-operator/v3/service/deletionpreview"
+)
+
+const (
+	// Method is the HTTP method this endpoint is registered for.
+	Method = "GET"
+	// Name identifies the endpoint. It is aligned to the package path.
+	Name = "deletionpreview"
+	// Path is the HTTP request path this endpoint is registered for.
+	Path = "/deletionpreview/"
+
+	// ClusterIDParam is the query parameter holding the ID of the tenant
+	// cluster to preview the deletion for.
+	ClusterIDParam = "cluster_id"
+)
+
+// Config represents the configuration used to create a deletion preview
+// endpoint.
+type Config struct {
+	Logger  micrologger.Logger
+	Service deletionpreview.Interface
+}
+
+// Endpoint returns the objects the deletion of a tenant cluster would delete
+// without changing anything.
+type Endpoint struct {
+	logger  micrologger.Logger
+	service deletionpreview.Interface
+}
+
+// New creates a new configured deletion preview endpoint.
+func New(config Config) (*Endpoint, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Service == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Service must not be empty", config)
+	}
+
+	e := &Endpoint{
+		logger:  config.Logger,
+		service: config.Service,
+	}
+
+	return e, nil
+}
+
+func (e *Endpoint) Decoder() kithttp.DecodeRequestFunc {
+	return func(ctx context.Context, r *http.Request) (interface{}, error) {
+		clusterID := r.URL.Query().Get(ClusterIDParam)
+		if clusterID == "" {
+			return nil, microerror.Maskf(invalidRequestError, "query parameter %#q must not be empty", ClusterIDParam)
+		}
+
+		return clusterID, nil
+	}
+}
+
+func (e *Endpoint) Encoder() kithttp.EncodeResponseFunc {
+	return func(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+		return json.NewEncoder(w).Encode(response)
+	}
+}
+
+func (e *Endpoint) Endpoint() kitendpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		p, err := e.service.Preview(ctx, request.(string))
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		return p, nil
+	}
+}
+
+func (e *Endpoint) Method() string {
+	return Method
+}
+
+func (e *Endpoint) Middlewares() []kitendpoint.Middleware {
+	return []kitendpoint.Middleware{}
+}
+
+func (e *Endpoint) Name() string {
+	return Name
+}
+
+func (e *Endpoint) Path() string {
+	return Path
+}
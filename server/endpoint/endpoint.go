@@ -6,6 +6,7 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 
+	"github.com/giantswarm/cluster-operator/v3/server/endpoint/deletionpreview"
 	"github.com/giantswarm/cluster-operator/v3/server/middleware"
 	"github.com/giantswarm/cluster-operator/v3/service"
 )
@@ -19,8 +20,9 @@ type Config struct {
 
 // Endpoint is the endpoint collection.
 type Endpoint struct {
-	Healthz *healthz.Endpoint
-	Version *version.Endpoint
+	DeletionPreview *deletionpreview.Endpoint
+	Healthz         *healthz.Endpoint
+	Version         *version.Endpoint
 }
 
 // New creates a new endpoint with given configuration.
@@ -35,6 +37,19 @@ func New(config Config) (*Endpoint, error) {
 		return nil, microerror.Maskf(invalidConfigError, "config.Service or it's Healthz descendents must not be empty")
 	}
 
+	var deletionPreviewEndpoint *deletionpreview.Endpoint
+	{
+		c := deletionpreview.Config{
+			Logger:  config.Logger,
+			Service: config.Service.DeletionPreview,
+		}
+
+		deletionPreviewEndpoint, err = deletionpreview.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var healthzEndpoint *healthz.Endpoint
 	{
 		c := healthz.Config{
@@ -61,8 +76,9 @@ func New(config Config) (*Endpoint, error) {
 	}
 
 	endpoint := &Endpoint{
-		Healthz: healthzEndpoint,
-		Version: versionEndpoint,
+		DeletionPreview: deletionPreviewEndpoint,
+		Healthz:         healthzEndpoint,
+		Version:         versionEndpoint,
 	}
 
 	return endpoint, nil
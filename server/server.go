@@ -11,8 +11,10 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/giantswarm/cluster-operator/v3/server/endpoint"
+	"github.com/giantswarm/cluster-operator/v3/server/endpoint/deletionpreview"
 	"github.com/giantswarm/cluster-operator/v3/server/middleware"
 	"github.com/giantswarm/cluster-operator/v3/service"
+	servicedeletionpreview "github.com/giantswarm/cluster-operator/v3/service/deletionpreview"
 )
 
 // Config represents the configuration used to construct server object.
@@ -88,6 +90,7 @@ func New(config Config) (*Server, error) {
 			Viper:       config.Viper,
 
 			Endpoints: []microserver.Endpoint{
+				endpointCollection.DeletionPreview,
 				endpointCollection.Healthz,
 				endpointCollection.Version,
 			},
@@ -119,7 +122,18 @@ func errorEncoder(ctx context.Context, err error, w http.ResponseWriter) {
 	rErr := err.(microserver.ResponseError)
 	uErr := rErr.Underlying()
 
-	rErr.SetCode(microserver.CodeInternalError)
-	rErr.SetMessage(uErr.Error())
-	w.WriteHeader(http.StatusInternalServerError)
+	switch {
+	case deletionpreview.IsInvalidRequest(uErr):
+		rErr.SetCode(microserver.CodeInvalidInput)
+		rErr.SetMessage(uErr.Error())
+		w.WriteHeader(http.StatusBadRequest)
+	case servicedeletionpreview.IsNotFound(uErr):
+		rErr.SetCode(microserver.CodeResourceNotFound)
+		rErr.SetMessage(uErr.Error())
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		rErr.SetCode(microserver.CodeInternalError)
+		rErr.SetMessage(uErr.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+	}
 }
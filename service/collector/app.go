@@ -0,0 +1,189 @@
+package collector
+
+import (
+	"context"
+	"time"
+
+	g8sv1alpha1 "github.com/giantswarm/apiextensions/v3/pkg/apis/application/v1alpha1"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/prometheus/client_golang/prometheus"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/pkg/project"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+const (
+	// appTypeManaged is the type of apps installed by cluster-operator as part
+	// of the release of a tenant cluster.
+	appTypeManaged = "managed"
+	// appTypeOptional is the type of apps installed by users into a tenant
+	// cluster.
+	appTypeOptional = "optional"
+)
+
+var (
+	appStatus *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemApp, "status"),
+		"Release status of a tenant cluster app as provided by the status of the App CR.",
+		[]string{
+			"cluster_id",
+			"app",
+			"catalog",
+			"type",
+			"status",
+		},
+		nil,
+	)
+	appVersionMismatch *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemApp, "version_mismatch"),
+		"Whether the deployed version of a tenant cluster app differs from the desired version of the App CR.",
+		[]string{
+			"cluster_id",
+			"app",
+			"catalog",
+			"type",
+			"deployed_version",
+			"desired_version",
+		},
+		nil,
+	)
+	appLastDeployed *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemApp, "last_deployed_seconds"),
+		"Duration in seconds since a tenant cluster app was last deployed as provided by the status of the App CR.",
+		[]string{
+			"cluster_id",
+			"app",
+			"catalog",
+			"type",
+		},
+		nil,
+	)
+)
+
+type AppConfig struct {
+	Logger micrologger.Logger
+	Reader client.Reader
+}
+
+// App reports the status of the managed and optional apps of tenant clusters.
+// Apps are found in the namespace of their tenant cluster. Managed apps are
+// the ones labelled as managed by cluster-operator.
+type App struct {
+	logger micrologger.Logger
+	reader client.Reader
+}
+
+func NewApp(config AppConfig) (*App, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Reader == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Reader must not be empty", config)
+	}
+
+	a := &App{
+		logger: config.Logger,
+		reader: config.Reader,
+	}
+
+	return a, nil
+}
+
+func (a *App) Collect(ch chan<- prometheus.Metric) error {
+	ctx := context.Background()
+
+	var clusters apiv1alpha2.ClusterList
+	{
+		err := a.reader.List(
+			ctx,
+			&clusters,
+			client.MatchingLabels{label.OperatorVersion: project.Version()},
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	// Apps are listed once for all tenant clusters instead of once per tenant
+	// cluster namespace.
+	var apps g8sv1alpha1.AppList
+	{
+		err := a.reader.List(ctx, &apps)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	clusterIDs := map[string]bool{}
+	for _, cl := range clusters.Items {
+		cl := cl // dereferencing pointer value into new scope
+
+		clusterIDs[key.ClusterID(&cl)] = true
+	}
+
+	for _, app := range apps.Items {
+		clusterID := app.GetNamespace()
+		if !clusterIDs[clusterID] {
+			continue
+		}
+
+		appType := appTypeOptional
+		if app.GetLabels()[label.ManagedBy] == project.Name() {
+			appType = appTypeManaged
+		}
+
+		var mismatch float64
+		if app.Status.Version != app.Spec.Version {
+			mismatch = 1
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			appStatus,
+			prometheus.GaugeValue,
+			GaugeValue,
+			clusterID,
+			app.GetName(),
+			app.Spec.Catalog,
+			appType,
+			app.Status.Release.Status,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			appVersionMismatch,
+			prometheus.GaugeValue,
+			mismatch,
+			clusterID,
+			app.GetName(),
+			app.Spec.Catalog,
+			appType,
+			app.Status.Version,
+			app.Spec.Version,
+		)
+
+		// Apps which were never deployed have no deployment time.
+		if !app.Status.Release.LastDeployed.IsZero() {
+			ch <- prometheus.MustNewConstMetric(
+				appLastDeployed,
+				prometheus.GaugeValue,
+				time.Since(app.Status.Release.LastDeployed.Time).Seconds(),
+				clusterID,
+				app.GetName(),
+				app.Spec.Catalog,
+				appType,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (a *App) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- appStatus
+	ch <- appVersionMismatch
+	ch <- appLastDeployed
+
+	return nil
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
-	"github.com/giantswarm/k8sclient/v5/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/prometheus/client_golang/prometheus"
@@ -32,34 +31,34 @@ var (
 )
 
 type ClusterConfig struct {
-	K8sClient k8sclient.Interface
-	Logger    micrologger.Logger
+	Logger micrologger.Logger
+	Reader client.Reader
 
 	NewCommonClusterObjectFunc func() infrastructurev1alpha2.CommonClusterObject
 }
 
 type Cluster struct {
-	k8sClient k8sclient.Interface
-	logger    micrologger.Logger
+	logger micrologger.Logger
+	reader client.Reader
 
 	newCommonClusterObjectFunc func() infrastructurev1alpha2.CommonClusterObject
 }
 
 func NewCluster(config ClusterConfig) (*Cluster, error) {
-	if config.K8sClient == nil {
-		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
-	}
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
+	if config.Reader == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Reader must not be empty", config)
+	}
 
 	if config.NewCommonClusterObjectFunc == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.NewCommonClusterObjectFunc must not be empty", config)
 	}
 
 	c := &Cluster{
-		k8sClient: config.K8sClient,
-		logger:    config.Logger,
+		logger: config.Logger,
+		reader: config.Reader,
 
 		newCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
 	}
@@ -72,7 +71,7 @@ func (c *Cluster) Collect(ch chan<- prometheus.Metric) error {
 
 	var list apiv1alpha2.ClusterList
 	{
-		err := c.k8sClient.CtrlClient().List(
+		err := c.reader.List(
 			ctx,
 			&list,
 			client.MatchingLabels{label.OperatorVersion: project.Version()},
@@ -87,7 +86,7 @@ func (c *Cluster) Collect(ch chan<- prometheus.Metric) error {
 
 		cr := c.newCommonClusterObjectFunc()
 		{
-			err := c.k8sClient.CtrlClient().Get(
+			err := c.reader.Get(
 				ctx,
 				key.ObjRefToNamespacedName(key.ObjRefFromCluster(cl)),
 				cr,
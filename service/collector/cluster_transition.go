@@ -6,10 +6,10 @@ import (
 	"time"
 
 	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
-	"github.com/giantswarm/k8sclient/v5/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -19,6 +19,15 @@ import (
 	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
 )
 
+const (
+	transitionCreation = "creation"
+	transitionUpdate   = "update"
+
+	// stuckReasonUnknown is the reason of stuck clusters not being reported as
+	// Degraded yet.
+	stuckReasonUnknown = "Unknown"
+)
+
 var (
 	clusterTransitionCreateDesc *prometheus.Desc = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, subsystemCluster, "create_transition"),
@@ -38,42 +47,69 @@ var (
 		},
 		nil,
 	)
+	clusterTransitionStuckDesc *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemCluster, "transition_stuck"),
+		"Cluster creation or update exceeding its threshold.",
+		[]string{
+			"cluster_id",
+			"release_version",
+			"transition",
+			"reason",
+		},
+		nil,
+	)
 )
 
 type ClusterTransitionConfig struct {
-	K8sClient k8sclient.Interface
-	Logger    micrologger.Logger
+	Logger micrologger.Logger
+	Reader client.Reader
 
+	// CreationThreshold is the duration after which a cluster still being
+	// created is reported as stuck.
+	CreationThreshold          time.Duration
 	NewCommonClusterObjectFunc func() infrastructurev1alpha2.CommonClusterObject
+	// UpdateThreshold is the duration after which a cluster still being
+	// updated is reported as stuck.
+	UpdateThreshold time.Duration
 }
 
 // ClusterTransition implements the ClusterTransition interface, exposing
 // cluster transition information.
 type ClusterTransition struct {
-	k8sClient k8sclient.Interface
-	logger    micrologger.Logger
+	logger micrologger.Logger
+	reader client.Reader
 
+	creationThreshold          time.Duration
 	newCommonClusterObjectFunc func() infrastructurev1alpha2.CommonClusterObject
+	updateThreshold            time.Duration
 }
 
 //NewClusterTransition initiates cluster transition metrics
 func NewClusterTransition(config ClusterTransitionConfig) (*ClusterTransition, error) {
-	if config.K8sClient == nil {
-		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
-	}
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
+	if config.Reader == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Reader must not be empty", config)
+	}
 
+	if config.CreationThreshold == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CreationThreshold must not be empty", config)
+	}
 	if config.NewCommonClusterObjectFunc == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.NewCommonClusterObjectFunc must not be empty", config)
 	}
+	if config.UpdateThreshold == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.UpdateThreshold must not be empty", config)
+	}
 
 	ct := &ClusterTransition{
-		k8sClient: config.K8sClient,
-		logger:    config.Logger,
+		logger: config.Logger,
+		reader: config.Reader,
 
+		creationThreshold:          config.CreationThreshold,
 		newCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
+		updateThreshold:            config.UpdateThreshold,
 	}
 
 	return ct, nil
@@ -84,7 +120,7 @@ func (ct *ClusterTransition) Collect(ch chan<- prometheus.Metric) error {
 
 	var list apiv1alpha2.ClusterList
 	{
-		err := ct.k8sClient.CtrlClient().List(
+		err := ct.reader.List(
 			ctx,
 			&list,
 			client.MatchingLabels{label.OperatorVersion: project.Version()},
@@ -99,7 +135,7 @@ func (ct *ClusterTransition) Collect(ch chan<- prometheus.Metric) error {
 
 		cr := ct.newCommonClusterObjectFunc()
 		{
-			err := ct.k8sClient.CtrlClient().Get(
+			err := ct.reader.Get(
 				ctx,
 				key.ObjRefToNamespacedName(key.ObjRefFromCluster(cl)),
 				cr,
@@ -112,75 +148,77 @@ func (ct *ClusterTransition) Collect(ch chan<- prometheus.Metric) error {
 			}
 		}
 
-		{
-			{
-				if cr.GetCommonClusterStatus().HasCreatingCondition() && cr.GetCommonClusterStatus().HasCreatedCondition() {
-					t1 := cr.GetCommonClusterStatus().GetCreatingCondition().LastTransitionTime.Time
-					t2 := cr.GetCommonClusterStatus().GetCreatedCondition().LastTransitionTime.Time
-					ch <- prometheus.MustNewConstMetric(
-						clusterTransitionCreateDesc,
-						prometheus.GaugeValue,
-						t2.Sub(t1).Seconds(),
-						key.ClusterID(cr),
-						key.ReleaseVersion(cr),
-					)
-				}
+		conditions := cr.GetCommonClusterStatus().Conditions
 
-				if cr.GetCommonClusterStatus().HasCreatingCondition() && !cr.GetCommonClusterStatus().HasCreatedCondition() {
-					t1 := cr.GetCommonClusterStatus().GetCreatingCondition().LastTransitionTime.Time
-
-					// If the Creating condition is too old without having any
-					// Created condition given, we put the cluster into the last
-					// bucket and consider it invalid in that regard.
-					if time.Now().After(t1.Add(30 * time.Minute)) {
-						ch <- prometheus.MustNewConstMetric(
-							clusterTransitionCreateDesc,
-							prometheus.GaugeValue,
-							float64(999999999999),
-							key.ClusterID(cr),
-							key.ReleaseVersion(cr),
-						)
-					}
-				}
+		creating := key.LatestClusterStatusCondition(conditions, infrastructurev1alpha2.ClusterStatusConditionCreating)
+		created := key.LatestClusterStatusCondition(conditions, infrastructurev1alpha2.ClusterStatusConditionCreated)
+		updating := key.LatestClusterStatusCondition(conditions, infrastructurev1alpha2.ClusterStatusConditionUpdating)
+		updated := key.LatestClusterStatusCondition(conditions, infrastructurev1alpha2.ClusterStatusConditionUpdated)
+
+		if !creating.LastTransitionTime.IsZero() && created.LastTransitionTime.After(creating.LastTransitionTime.Time) {
+			ch <- prometheus.MustNewConstMetric(
+				clusterTransitionCreateDesc,
+				prometheus.GaugeValue,
+				created.LastTransitionTime.Sub(creating.LastTransitionTime.Time).Seconds(),
+				key.ClusterID(cr),
+				key.ReleaseVersion(cr),
+			)
+		}
+
+		if !updating.LastTransitionTime.IsZero() && updated.LastTransitionTime.After(updating.LastTransitionTime.Time) {
+			ch <- prometheus.MustNewConstMetric(
+				clusterTransitionUpdateDesc,
+				prometheus.GaugeValue,
+				updated.LastTransitionTime.Sub(updating.LastTransitionTime.Time).Seconds(),
+				key.ClusterID(cr),
+				key.ReleaseVersion(cr),
+			)
+		}
+
+		// Clusters still being created or updated after the configured
+		// threshold are reported as stuck. The reason is the one of the
+		// Degraded condition, which uses the same thresholds.
+		{
+			var transition string
+			var since time.Time
+			var threshold time.Duration
+			switch key.LatestClusterStatusCondition(conditions, "").Condition {
+			case infrastructurev1alpha2.ClusterStatusConditionCreating:
+				transition = transitionCreation
+				since = creating.LastTransitionTime.Time
+				threshold = ct.creationThreshold
+			case infrastructurev1alpha2.ClusterStatusConditionUpdating:
+				transition = transitionUpdate
+				since = updating.LastTransitionTime.Time
+				threshold = ct.updateThreshold
 			}
-			{
-				if cr.GetCommonClusterStatus().HasUpdatingCondition() && cr.GetCommonClusterStatus().HasUpdatedCondition() {
-					t1 := cr.GetCommonClusterStatus().GetUpdatingCondition().LastTransitionTime.Time
-					t2 := cr.GetCommonClusterStatus().GetUpdatedCondition().LastTransitionTime.Time
-					ch <- prometheus.MustNewConstMetric(
-						clusterTransitionUpdateDesc,
-						prometheus.GaugeValue,
-						t2.Sub(t1).Seconds(),
-						key.ClusterID(cr),
-						key.ReleaseVersion(cr),
-					)
-				}
 
-				if cr.GetCommonClusterStatus().HasUpdatingCondition() && !cr.GetCommonClusterStatus().HasUpdatedCondition() {
-					t1 := cr.GetCommonClusterStatus().GetUpdatingCondition().LastTransitionTime.Time
-
-					// If the Updating condition is too old without having any
-					// Updated condition given, we put the cluster into the last
-					// bucket and consider it invalid in that regard.
-					if time.Now().After(t1.Add(2 * time.Hour)) {
-						ch <- prometheus.MustNewConstMetric(
-							clusterTransitionCreateDesc,
-							prometheus.GaugeValue,
-							float64(999999999999),
-							key.ClusterID(cr),
-							key.ReleaseVersion(cr),
-						)
-					}
+			if transition != "" && time.Now().After(since.Add(threshold)) {
+				reason := stuckReasonUnknown
+				if c := key.Condition(key.Conditions(&cl), key.DegradedCondition); c != nil && c.Status == corev1.ConditionTrue {
+					reason = c.Reason
 				}
+
+				ch <- prometheus.MustNewConstMetric(
+					clusterTransitionStuckDesc,
+					prometheus.GaugeValue,
+					GaugeValue,
+					key.ClusterID(cr),
+					key.ReleaseVersion(cr),
+					transition,
+					reason,
+				)
 			}
 		}
 	}
+
 	return nil
 }
 
 func (ct *ClusterTransition) Describe(ch chan<- *prometheus.Desc) error {
 	ch <- clusterTransitionCreateDesc
 	ch <- clusterTransitionUpdateDesc
+	ch <- clusterTransitionStuckDesc
 
 	return nil
 }
@@ -1,8 +1,13 @@
 package collector
 
 const (
-	GaugeValue        float64 = 1
-	namespace         string  = "cluster_operator"
-	subsystemCluster  string  = "cluster"
-	subsystemNodePool string  = "node_pool"
+	GaugeValue               float64 = 1
+	namespace                string  = "cluster_operator"
+	subsystemApp             string  = "app"
+	subsystemCluster         string  = "cluster"
+	subsystemCollector       string  = "collector"
+	subsystemNodePool        string  = "node_pool"
+	subsystemOrphan          string  = "orphan"
+	subsystemReconciliation  string  = "reconciliation"
+	subsystemUpgradeProgress string  = "upgrade_progress"
 )
@@ -0,0 +1,93 @@
+package collector
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/prometheus/client_golang/prometheus"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/pkg/project"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+var (
+	componentHealthy *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemCluster, "component_healthy"),
+		"Health of tenant cluster components as provided by the component health probes of the Cluster CR.",
+		[]string{
+			"cluster_id",
+			"component",
+			"reason",
+		},
+		nil,
+	)
+)
+
+type ComponentHealthConfig struct {
+	Logger micrologger.Logger
+	Reader client.Reader
+}
+
+type ComponentHealth struct {
+	logger micrologger.Logger
+	reader client.Reader
+}
+
+func NewComponentHealth(config ComponentHealthConfig) (*ComponentHealth, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Reader == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Reader must not be empty", config)
+	}
+
+	ch := &ComponentHealth{
+		logger: config.Logger,
+		reader: config.Reader,
+	}
+
+	return ch, nil
+}
+
+func (c *ComponentHealth) Collect(ch chan<- prometheus.Metric) error {
+	ctx := context.Background()
+
+	var list apiv1alpha2.ClusterList
+	{
+		err := c.reader.List(
+			ctx,
+			&list,
+			client.MatchingLabels{label.OperatorVersion: project.Version()},
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	for _, cl := range list.Items {
+		cl := cl // dereferencing pointer value into new scope
+
+		for _, h := range key.ComponentHealthFromAnnotation(&cl) {
+			ch <- prometheus.MustNewConstMetric(
+				componentHealthy,
+				prometheus.GaugeValue,
+				boolToFloat64(h.Healthy),
+				key.ClusterID(&cl),
+				h.Component,
+				h.Reason,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (c *ComponentHealth) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- componentHealthy
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package collector
+
+import (
+	"context"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/prometheus/client_golang/prometheus"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/pkg/project"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+var (
+	deletionPhaseDuration *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemCluster, "deletion_phase_seconds"),
+		"Duration in seconds the tenant cluster has been in its current deletion phase as provided by the deletion phase annotation of the Cluster CR.",
+		[]string{
+			"cluster_id",
+			"phase",
+		},
+		nil,
+	)
+	deletionPhaseOverrun *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemCluster, "deletion_phase_overrun"),
+		"Whether the current deletion phase of the tenant cluster exceeded its timeout.",
+		[]string{
+			"cluster_id",
+			"phase",
+		},
+		nil,
+	)
+)
+
+type DeletionPhaseConfig struct {
+	Logger micrologger.Logger
+	Reader client.Reader
+}
+
+type DeletionPhase struct {
+	logger micrologger.Logger
+	reader client.Reader
+}
+
+func NewDeletionPhase(config DeletionPhaseConfig) (*DeletionPhase, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Reader == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Reader must not be empty", config)
+	}
+
+	d := &DeletionPhase{
+		logger: config.Logger,
+		reader: config.Reader,
+	}
+
+	return d, nil
+}
+
+func (d *DeletionPhase) Collect(ch chan<- prometheus.Metric) error {
+	ctx := context.Background()
+
+	var list apiv1alpha2.ClusterList
+	{
+		err := d.reader.List(
+			ctx,
+			&list,
+			client.MatchingLabels{label.OperatorVersion: project.Version()},
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	for _, cl := range list.Items {
+		cl := cl // dereferencing pointer value into new scope
+
+		if cl.GetDeletionTimestamp() == nil {
+			continue
+		}
+
+		p, ok := key.DeletionPhaseFromAnnotation(&cl)
+		if !ok {
+			continue
+		}
+
+		var overrun float64
+		if p.Overrun {
+			overrun = 1
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			deletionPhaseDuration,
+			prometheus.GaugeValue,
+			time.Since(p.Since.Time).Seconds(),
+			key.ClusterID(&cl),
+			p.Name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			deletionPhaseOverrun,
+			prometheus.GaugeValue,
+			overrun,
+			key.ClusterID(&cl),
+			p.Name,
+		)
+	}
+
+	return nil
+}
+
+func (d *DeletionPhase) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- deletionPhaseDuration
+	ch <- deletionPhaseOverrun
+
+	return nil
+}
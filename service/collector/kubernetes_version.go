@@ -0,0 +1,140 @@
+package collector
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/prometheus/client_golang/prometheus"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/pkg/project"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+const (
+	componentAPIServer string = "apiserver"
+	componentKubelet   string = "kubelet"
+)
+
+var (
+	kubernetesVersionInfo *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemCluster, "kubernetes_version"),
+		"Number of tenant API servers and kubelets running a Kubernetes version as provided by the Kubernetes versions of the Cluster CR.",
+		[]string{
+			"cluster_id",
+			"component",
+			"version",
+			"desired_version",
+		},
+		nil,
+	)
+
+	kubernetesMinorVersionSkew *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemCluster, "kubernetes_minor_version_skew"),
+		"Number of minor versions the tenant API server or the oldest kubelet is behind the Kubernetes version of the release as provided by the Kubernetes versions of the Cluster CR.",
+		[]string{
+			"cluster_id",
+			"component",
+		},
+		nil,
+	)
+)
+
+type KubernetesVersionConfig struct {
+	Logger micrologger.Logger
+	Reader client.Reader
+}
+
+type KubernetesVersion struct {
+	logger micrologger.Logger
+	reader client.Reader
+}
+
+func NewKubernetesVersion(config KubernetesVersionConfig) (*KubernetesVersion, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Reader == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Reader must not be empty", config)
+	}
+
+	kv := &KubernetesVersion{
+		logger: config.Logger,
+		reader: config.Reader,
+	}
+
+	return kv, nil
+}
+
+func (k *KubernetesVersion) Collect(ch chan<- prometheus.Metric) error {
+	ctx := context.Background()
+
+	var list apiv1alpha2.ClusterList
+	{
+		err := k.reader.List(
+			ctx,
+			&list,
+			client.MatchingLabels{label.OperatorVersion: project.Version()},
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	for _, cl := range list.Items {
+		cl := cl // dereferencing pointer value into new scope
+
+		kv, ok := key.KubernetesVersionFromAnnotation(&cl)
+		if !ok {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			kubernetesVersionInfo,
+			prometheus.GaugeValue,
+			GaugeValue,
+			key.ClusterID(&cl),
+			componentAPIServer,
+			kv.Server,
+			kv.Desired,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			kubernetesMinorVersionSkew,
+			prometheus.GaugeValue,
+			float64(kv.ServerMinorSkew),
+			key.ClusterID(&cl),
+			componentAPIServer,
+		)
+
+		for v, n := range kv.Kubelets {
+			ch <- prometheus.MustNewConstMetric(
+				kubernetesVersionInfo,
+				prometheus.GaugeValue,
+				float64(n),
+				key.ClusterID(&cl),
+				componentKubelet,
+				v,
+				kv.Desired,
+			)
+		}
+		ch <- prometheus.MustNewConstMetric(
+			kubernetesMinorVersionSkew,
+			prometheus.GaugeValue,
+			float64(kv.KubeletMinorSkew),
+			key.ClusterID(&cl),
+			componentKubelet,
+		)
+	}
+
+	return nil
+}
+
+func (k *KubernetesVersion) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- kubernetesVersionInfo
+	ch <- kubernetesMinorVersionSkew
+
+	return nil
+}
@@ -3,7 +3,6 @@ package collector
 import (
 	"context"
 
-	"github.com/giantswarm/k8sclient/v5/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/prometheus/client_golang/prometheus"
@@ -47,26 +46,26 @@ var (
 )
 
 type NodePoolConfig struct {
-	K8sClient k8sclient.Interface
-	Logger    micrologger.Logger
+	Logger micrologger.Logger
+	Reader client.Reader
 }
 
 type NodePool struct {
-	k8sClient k8sclient.Interface
-	logger    micrologger.Logger
+	logger micrologger.Logger
+	reader client.Reader
 }
 
 func NewNodePool(config NodePoolConfig) (*NodePool, error) {
-	if config.K8sClient == nil {
-		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
-	}
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
+	if config.Reader == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Reader must not be empty", config)
+	}
 
 	np := &NodePool{
-		k8sClient: config.K8sClient,
-		logger:    config.Logger,
+		logger: config.Logger,
+		reader: config.Reader,
 	}
 
 	return np, nil
@@ -77,7 +76,7 @@ func (np *NodePool) Collect(ch chan<- prometheus.Metric) error {
 
 	var list apiv1alpha2.MachineDeploymentList
 	{
-		err := np.k8sClient.CtrlClient().List(
+		err := np.reader.List(
 			ctx,
 			&list,
 			client.MatchingLabels{label.OperatorVersion: project.Version()},
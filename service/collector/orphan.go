@@ -0,0 +1,105 @@
+package collector
+
+import (
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/giantswarm/cluster-operator/v3/service/internal/orphan"
+)
+
+var (
+	orphanObjects *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemOrphan, "objects"),
+		"Number of management cluster objects whose tenant cluster does not exist anymore.",
+		[]string{
+			"cluster_id",
+			"kind",
+		},
+		nil,
+	)
+	orphanAge *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemOrphan, "max_age_seconds"),
+		"Duration in seconds the longest orphaned object of the given kind has been orphaned for.",
+		[]string{
+			"cluster_id",
+			"kind",
+		},
+		nil,
+	)
+)
+
+type OrphanConfig struct {
+	Logger  micrologger.Logger
+	Sweeper *orphan.Sweeper
+}
+
+// Orphan reports the orphaned objects found by the last sweep of the orphan
+// sweeper, so that scraping does not list all objects of the management
+// cluster.
+type Orphan struct {
+	logger  micrologger.Logger
+	sweeper *orphan.Sweeper
+}
+
+func NewOrphan(config OrphanConfig) (*Orphan, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Sweeper == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Sweeper must not be empty", config)
+	}
+
+	o := &Orphan{
+		logger:  config.Logger,
+		sweeper: config.Sweeper,
+	}
+
+	return o, nil
+}
+
+func (o *Orphan) Collect(ch chan<- prometheus.Metric) error {
+	type group struct {
+		clusterID string
+		kind      string
+	}
+
+	counts := map[group]int{}
+	ages := map[group]time.Duration{}
+	for _, x := range o.sweeper.Orphaned() {
+		g := group{clusterID: x.ClusterID, kind: x.Kind}
+
+		counts[g]++
+		if age := time.Since(x.FirstSeen); age > ages[g] {
+			ages[g] = age
+		}
+	}
+
+	for g, n := range counts {
+		ch <- prometheus.MustNewConstMetric(
+			orphanObjects,
+			prometheus.GaugeValue,
+			float64(n),
+			g.clusterID,
+			g.kind,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			orphanAge,
+			prometheus.GaugeValue,
+			ages[g].Seconds(),
+			g.clusterID,
+			g.kind,
+		)
+	}
+
+	return nil
+}
+
+func (o *Orphan) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- orphanObjects
+	ch <- orphanAge
+
+	return nil
+}
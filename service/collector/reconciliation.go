@@ -0,0 +1,121 @@
+package collector
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/giantswarm/cluster-operator/v3/service/internal/reconciliation"
+)
+
+var (
+	reconciliationDuration *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemReconciliation, "cluster_duration_seconds"),
+		"Duration in seconds of the latest reconciliation loop of a tenant cluster.",
+		[]string{
+			"cluster_id",
+			"controller",
+		},
+		nil,
+	)
+	reconciliationLastSuccess *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemReconciliation, "cluster_last_success_timestamp_seconds"),
+		"Unix time of the latest successful reconciliation loop of a tenant cluster.",
+		[]string{
+			"cluster_id",
+			"controller",
+		},
+		nil,
+	)
+	reconciliationConsecutiveErrors *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemReconciliation, "cluster_consecutive_errors"),
+		"Number of reconciliation loops of a tenant cluster which failed in a row.",
+		[]string{
+			"cluster_id",
+			"controller",
+		},
+		nil,
+	)
+	reconciliationUntrackedClusters *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemReconciliation, "untracked_clusters"),
+		"Number of tenant clusters no per cluster reconciliation metrics are exported for because of the configured limit.",
+		nil,
+		nil,
+	)
+)
+
+type ReconciliationConfig struct {
+	Logger  micrologger.Logger
+	Tracker *reconciliation.Tracker
+}
+
+// Reconciliation reports the reconciliation state of tenant clusters as
+// recorded by the reconciliation tracker of the controllers.
+type Reconciliation struct {
+	logger  micrologger.Logger
+	tracker *reconciliation.Tracker
+}
+
+func NewReconciliation(config ReconciliationConfig) (*Reconciliation, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Tracker == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Tracker must not be empty", config)
+	}
+
+	r := &Reconciliation{
+		logger:  config.Logger,
+		tracker: config.Tracker,
+	}
+
+	return r, nil
+}
+
+func (r *Reconciliation) Collect(ch chan<- prometheus.Metric) error {
+	for _, c := range r.tracker.Clusters() {
+		ch <- prometheus.MustNewConstMetric(
+			reconciliationDuration,
+			prometheus.GaugeValue,
+			c.Duration.Seconds(),
+			c.ClusterID,
+			c.Controller,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			reconciliationConsecutiveErrors,
+			prometheus.GaugeValue,
+			float64(c.ConsecutiveErrors),
+			c.ClusterID,
+			c.Controller,
+		)
+
+		// Tenant clusters which never reconciled successfully have no time of
+		// the latest success.
+		if !c.LastSuccess.IsZero() {
+			ch <- prometheus.MustNewConstMetric(
+				reconciliationLastSuccess,
+				prometheus.GaugeValue,
+				float64(c.LastSuccess.Unix()),
+				c.ClusterID,
+				c.Controller,
+			)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		reconciliationUntrackedClusters,
+		prometheus.GaugeValue,
+		float64(r.tracker.Untracked()),
+	)
+
+	return nil
+}
+
+func (r *Reconciliation) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- reconciliationDuration
+	ch <- reconciliationLastSuccess
+	ch <- reconciliationConsecutiveErrors
+	ch <- reconciliationUntrackedClusters
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package collector
+
+import (
+	"time"
+
+	"github.com/giantswarm/exporterkit/collector"
+	"github.com/giantswarm/microerror"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	scrapeDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystemCollector,
+			Name:      "scrape_duration_seconds",
+			Help:      "Duration of collecting the metrics of a collector.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"collector"},
+	)
+	scrapeErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystemCollector,
+			Name:      "scrape_errors_total",
+			Help:      "Number of failed metric collections of a collector.",
+		},
+		[]string{"collector"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(scrapeDuration)
+	prometheus.MustRegister(scrapeErrors)
+}
+
+// instrumented wraps a collector and records the duration and the errors of
+// its metric collections.
+type instrumented struct {
+	collector.Interface
+
+	name string
+}
+
+func instrument(name string, c collector.Interface) collector.Interface {
+	// The error counter is initialized so that collectors which never failed
+	// are reported with zero errors.
+	scrapeErrors.WithLabelValues(name)
+
+	return &instrumented{
+		Interface: c,
+
+		name: name,
+	}
+}
+
+func (i *instrumented) Collect(ch chan<- prometheus.Metric) error {
+	start := time.Now()
+	err := i.Interface.Collect(ch)
+	scrapeDuration.WithLabelValues(i.name).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		scrapeErrors.WithLabelValues(i.name).Inc()
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -1,37 +1,90 @@
 package collector
 
 import (
+	"context"
+	"time"
+
+	g8sv1alpha1 "github.com/giantswarm/apiextensions/v3/pkg/apis/application/v1alpha1"
 	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
 	"github.com/giantswarm/certs/v3/pkg/certs"
 	"github.com/giantswarm/exporterkit/collector"
 	"github.com/giantswarm/k8sclient/v5/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
+	"k8s.io/apimachinery/pkg/runtime"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	"github.com/giantswarm/cluster-operator/v3/service/internal/orphan"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/reconciliation"
 )
 
 type SetConfig struct {
-	CertSearcher certs.Interface
-	K8sClient    k8sclient.Interface
-	Logger       micrologger.Logger
+	CertSearcher          certs.Interface
+	K8sClient             k8sclient.Interface
+	Logger                micrologger.Logger
+	OrphanSweeper         *orphan.Sweeper
+	ReconciliationTracker *reconciliation.Tracker
 
+	DegradedCreationThreshold  time.Duration
+	DegradedUpdateThreshold    time.Duration
 	NewCommonClusterObjectFunc func() infrastructurev1alpha2.CommonClusterObject
 }
 
 // Set is basically only a wrapper for the operator's collector implementations.
 // It eases the initialization and prevents some weird import mess so we do not
-// have to alias packages.
+// have to alias packages. The collectors read the management cluster objects
+// from shared informer caches, so that scrapes do not list them from the API
+// server.
 type Set struct {
 	*collector.Set
+
+	cache  cache.Cache
+	logger micrologger.Logger
+
+	newCommonClusterObjectFunc func() infrastructurev1alpha2.CommonClusterObject
 }
 
 func NewSet(config SetConfig) (*Set, error) {
 	var err error
 
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	var informerCache cache.Cache
+	{
+		c := cache.Options{
+			Scheme: config.K8sClient.Scheme(),
+		}
+
+		informerCache, err = cache.New(config.K8sClient.RESTConfig(), c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var appCollector *App
+	{
+		c := AppConfig{
+			Logger: config.Logger,
+			Reader: informerCache,
+		}
+
+		appCollector, err = NewApp(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var clusterCollector *Cluster
 	{
 		c := ClusterConfig{
-			K8sClient: config.K8sClient,
-			Logger:    config.Logger,
+			Logger: config.Logger,
+			Reader: informerCache,
 
 			NewCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
 		}
@@ -45,8 +98,8 @@ func NewSet(config SetConfig) (*Set, error) {
 	var nodePoolCollector *NodePool
 	{
 		c := NodePoolConfig{
-			K8sClient: config.K8sClient,
-			Logger:    config.Logger,
+			Logger: config.Logger,
+			Reader: informerCache,
 		}
 
 		nodePoolCollector, err = NewNodePool(c)
@@ -58,10 +111,12 @@ func NewSet(config SetConfig) (*Set, error) {
 	var clusterTransitionCollector *ClusterTransition
 	{
 		c := ClusterTransitionConfig{
-			K8sClient: config.K8sClient,
-			Logger:    config.Logger,
+			Logger: config.Logger,
+			Reader: informerCache,
 
+			CreationThreshold:          config.DegradedCreationThreshold,
 			NewCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
+			UpdateThreshold:            config.DegradedUpdateThreshold,
 		}
 
 		clusterTransitionCollector, err = NewClusterTransition(c)
@@ -70,13 +125,98 @@ func NewSet(config SetConfig) (*Set, error) {
 		}
 	}
 
+	var componentHealthCollector *ComponentHealth
+	{
+		c := ComponentHealthConfig{
+			Logger: config.Logger,
+			Reader: informerCache,
+		}
+
+		componentHealthCollector, err = NewComponentHealth(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var deletionPhaseCollector *DeletionPhase
+	{
+		c := DeletionPhaseConfig{
+			Logger: config.Logger,
+			Reader: informerCache,
+		}
+
+		deletionPhaseCollector, err = NewDeletionPhase(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var kubernetesVersionCollector *KubernetesVersion
+	{
+		c := KubernetesVersionConfig{
+			Logger: config.Logger,
+			Reader: informerCache,
+		}
+
+		kubernetesVersionCollector, err = NewKubernetesVersion(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var orphanCollector *Orphan
+	{
+		c := OrphanConfig{
+			Logger:  config.Logger,
+			Sweeper: config.OrphanSweeper,
+		}
+
+		orphanCollector, err = NewOrphan(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var reconciliationCollector *Reconciliation
+	{
+		c := ReconciliationConfig{
+			Logger:  config.Logger,
+			Tracker: config.ReconciliationTracker,
+		}
+
+		reconciliationCollector, err = NewReconciliation(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var upgradeProgressCollector *UpgradeProgress
+	{
+		c := UpgradeProgressConfig{
+			Logger: config.Logger,
+			Reader: informerCache,
+		}
+
+		upgradeProgressCollector, err = NewUpgradeProgress(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var collectorSet *collector.Set
 	{
 		c := collector.SetConfig{
 			Collectors: []collector.Interface{
-				clusterCollector,
-				nodePoolCollector,
-				clusterTransitionCollector,
+				instrument("cluster", clusterCollector),
+				instrument("node_pool", nodePoolCollector),
+				instrument("cluster_transition", clusterTransitionCollector),
+				instrument("upgrade_progress", upgradeProgressCollector),
+				instrument("component_health", componentHealthCollector),
+				instrument("deletion_phase", deletionPhaseCollector),
+				instrument("kubernetes_version", kubernetesVersionCollector),
+				instrument("orphan", orphanCollector),
+				instrument("app", appCollector),
+				instrument("reconciliation", reconciliationCollector),
 			},
 			Logger: config.Logger,
 		}
@@ -89,7 +229,57 @@ func NewSet(config SetConfig) (*Set, error) {
 
 	s := &Set{
 		Set: collectorSet,
+
+		cache:  informerCache,
+		logger: config.Logger,
+
+		newCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
 	}
 
 	return s, nil
 }
+
+// Boot starts the informer caches the collectors read from and registers the
+// collectors once the caches are synced. The informers of all kinds the
+// collectors read are requested upfront, so that scrapes never block on
+// starting and syncing informers.
+func (s *Set) Boot(ctx context.Context) error {
+	objs := []runtime.Object{
+		&apiv1alpha2.Cluster{},
+		&apiv1alpha2.MachineDeployment{},
+		&g8sv1alpha1.App{},
+		s.newCommonClusterObjectFunc(),
+	}
+
+	for _, obj := range objs {
+		_, err := s.cache.GetInformer(ctx, obj)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	go func() {
+		err := s.cache.Start(ctx.Done())
+		if err != nil {
+			s.logger.Errorf(ctx, err, "failed to run informer caches of collectors")
+		}
+	}()
+
+	s.logger.Debugf(ctx, "waiting for informer caches of collectors to sync")
+
+	// Waiting only fails once the context is done, which means the operator is
+	// shutting down.
+	if !s.cache.WaitForCacheSync(ctx.Done()) {
+		s.logger.Debugf(ctx, "stopped waiting for informer caches of collectors to sync")
+		return nil
+	}
+
+	s.logger.Debugf(ctx, "informer caches of collectors synced")
+
+	err := s.Set.Boot(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package collector
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/prometheus/client_golang/prometheus"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/pkg/project"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+const (
+	roleMaster string = "master"
+	roleWorker string = "worker"
+)
+
+var upgradeProgressLabels = []string{
+	"cluster_id",
+	"role",
+	"node_pool_id",
+	"version",
+}
+
+var (
+	upgradeProgressDesired *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemUpgradeProgress, "desired_nodes"),
+		"Number of desired nodes of the control plane or a node pool as provided by the upgrade progress of the Cluster CR.",
+		upgradeProgressLabels,
+		nil,
+	)
+
+	upgradeProgressNewVersion *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemUpgradeProgress, "new_version_nodes"),
+		"Number of nodes of the control plane or a node pool running the desired version as provided by the upgrade progress of the Cluster CR.",
+		upgradeProgressLabels,
+		nil,
+	)
+
+	upgradeProgressOldVersion *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemUpgradeProgress, "old_version_nodes"),
+		"Number of nodes of the control plane or a node pool not running the desired version as provided by the upgrade progress of the Cluster CR.",
+		upgradeProgressLabels,
+		nil,
+	)
+
+	upgradeProgressReady *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemUpgradeProgress, "ready_nodes"),
+		"Number of ready nodes of the control plane or a node pool as provided by the upgrade progress of the Cluster CR.",
+		upgradeProgressLabels,
+		nil,
+	)
+)
+
+type UpgradeProgressConfig struct {
+	Logger micrologger.Logger
+	Reader client.Reader
+}
+
+type UpgradeProgress struct {
+	logger micrologger.Logger
+	reader client.Reader
+}
+
+func NewUpgradeProgress(config UpgradeProgressConfig) (*UpgradeProgress, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Reader == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Reader must not be empty", config)
+	}
+
+	u := &UpgradeProgress{
+		logger: config.Logger,
+		reader: config.Reader,
+	}
+
+	return u, nil
+}
+
+func (u *UpgradeProgress) Collect(ch chan<- prometheus.Metric) error {
+	ctx := context.Background()
+
+	var list apiv1alpha2.ClusterList
+	{
+		err := u.reader.List(
+			ctx,
+			&list,
+			client.MatchingLabels{label.OperatorVersion: project.Version()},
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	for _, cl := range list.Items {
+		cl := cl // dereferencing pointer value into new scope
+
+		p, ok := key.UpgradeProgressFromAnnotation(&cl)
+		if !ok {
+			continue
+		}
+
+		collectNodeProgress(ch, p.ControlPlane, key.ClusterID(&cl), roleMaster, "", p.Version)
+
+		for id, np := range p.NodePools {
+			collectNodeProgress(ch, np, key.ClusterID(&cl), roleWorker, id, p.Version)
+		}
+	}
+
+	return nil
+}
+
+func (u *UpgradeProgress) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- upgradeProgressDesired
+	ch <- upgradeProgressNewVersion
+	ch <- upgradeProgressOldVersion
+	ch <- upgradeProgressReady
+
+	return nil
+}
+
+func collectNodeProgress(ch chan<- prometheus.Metric, np key.NodeProgress, labelValues ...string) {
+	ch <- prometheus.MustNewConstMetric(
+		upgradeProgressDesired,
+		prometheus.GaugeValue,
+		float64(np.Desired),
+		labelValues...,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		upgradeProgressNewVersion,
+		prometheus.GaugeValue,
+		float64(np.NewVersion),
+		labelValues...,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		upgradeProgressOldVersion,
+		prometheus.GaugeValue,
+		float64(np.OldVersion),
+		labelValues...,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		upgradeProgressReady,
+		prometheus.GaugeValue,
+		float64(np.Ready),
+		labelValues...,
+	)
+}
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"time"
+
 	"github.com/giantswarm/apiextensions/v3/pkg/annotation"
 	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
 	"github.com/giantswarm/certs/v3/pkg/certs"
@@ -31,20 +33,30 @@ import (
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/clusterconfigmap"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/clusterid"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/clusterstatus"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/componentshealthy"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/cpnamespace"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/deletecrs"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/deleteinfrarefs"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/deletionphase"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/deletionprotection"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/encryptionkey"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/keepforcrs"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/keepforinfrarefs"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/kubeconfig"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/kubeconfigrbac"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/podcidrallocation"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/registrypullsecret"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/statuscondition"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/tenantcleanup"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/updateg8scontrolplanes"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/updateinfrarefs"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/updatemachinedeployments"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/basedomain"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/clusterip"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/deletionblocker"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/hamaster"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/podcidr"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/reconciliation"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/recorder"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/releaseversion"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/tenantclient"
@@ -53,38 +65,89 @@ import (
 // ClusterConfig contains necessary dependencies and settings for CAPI's Cluster
 // CRD controller implementation.
 type ClusterConfig struct {
-	BaseDomain     basedomain.Interface
-	CertsSearcher  certs.Interface
-	Event          recorder.Interface
-	FileSystem     afero.Fs
-	K8sClient      k8sclient.Interface
-	Logger         micrologger.Logger
-	PodCIDR        podcidr.Interface
-	Tenant         tenantcluster.Interface
-	ReleaseVersion releaseversion.Interface
-
-	APIIP                      string
-	CertTTL                    string
-	ClusterIPRange             string
-	DNSIP                      string
-	ClusterDomain              string
-	NewCommonClusterObjectFunc func() infrastructurev1alpha2.CommonClusterObject
-	Provider                   string
-	RawAppDefaultConfig        string
-	RawAppOverrideConfig       string
-	RegistryDomain             string
+	BaseDomain            basedomain.Interface
+	CertsSearcher         certs.Interface
+	ClusterIP             clusterip.Interface
+	Event                 recorder.Interface
+	FileSystem            afero.Fs
+	K8sClient             k8sclient.Interface
+	Logger                micrologger.Logger
+	PodCIDR               podcidr.Interface
+	Tenant                tenantcluster.Interface
+	ReconciliationTracker *reconciliation.Tracker
+	ReleaseVersion        releaseversion.Interface
+
+	CertTTL                     string
+	ClusterDomain               string
+	ClusterValuesTemplate       string
+	DegradedCreationThreshold   time.Duration
+	DegradedUpdateThreshold     time.Duration
+	DeletionBlockerMaxAge       time.Duration
+	DeletionPhaseTimeouts       map[string]time.Duration
+	HealthProbes                []key.HealthProbe
+	IngressProviderDefaults     bool
+	KubeConfigCAPISecret        bool
+	KubeConfigProfiles          []key.KubeConfigProfile
+	NewCommonClusterObjectFunc  func() infrastructurev1alpha2.CommonClusterObject
+	Provider                    string
+	RawAppDefaultConfig         string
+	RawAppOverrideConfig        string
+	RegistryDomain              string
+	RegistryMirrors             []string
+	RegistryPullSecretName      string
+	RegistryPullSecretNamespace string
+	TenantCleanupTimeout        time.Duration
 }
 
 type Cluster struct {
 	*controller.Controller
+
+	k8sClient k8sclient.Interface
+	logger    micrologger.Logger
+	resources []resource.Interface
 }
 
 func NewCluster(config ClusterConfig) (*Cluster, error) {
 	var err error
 
+	// The unwrapped resources are kept for previewing the deletion of tenant
+	// clusters, which requires access to the resource implementations.
+	var unwrapped []resource.Interface
+	{
+		unwrapped, err = newClusterResources(config)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var resources []resource.Interface
 	{
-		resources, err = newClusterResources(config)
+		c := retryresource.WrapConfig{
+			Logger: config.Logger,
+		}
+
+		resources, err = retryresource.Wrap(unwrapped, c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	{
+		c := metricsresource.WrapConfig{}
+		resources, err = metricsresource.Wrap(resources, c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	{
+		c := reconciliation.WrapConfig{
+			Tracker: config.ReconciliationTracker,
+
+			Controller: "cluster",
+		}
+
+		resources, err = reconciliation.Wrap(resources, c)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
@@ -116,6 +179,10 @@ func NewCluster(config ClusterConfig) (*Cluster, error) {
 
 	c := &Cluster{
 		Controller: clusterController,
+
+		k8sClient: config.K8sClient,
+		logger:    config.Logger,
+		resources: unwrapped,
 	}
 
 	return c, nil
@@ -153,6 +220,21 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 		}
 	}
 
+	var deletionBlocker deletionblocker.Interface
+	{
+		c := deletionblocker.Config{
+			Event:     config.Event,
+			K8sClient: config.K8sClient,
+
+			MaxAge: config.DeletionBlockerMaxAge,
+		}
+
+		deletionBlocker, err = deletionblocker.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var appGetter appresource.StateGetter
 	{
 		c := app.Config{
@@ -216,15 +298,16 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 	{
 		c := certconfig.Config{
 			BaseDomain:     config.BaseDomain,
+			ClusterIP:      config.ClusterIP,
 			G8sClient:      config.K8sClient.G8sClient(),
 			HAMaster:       haMaster,
 			Logger:         config.Logger,
 			ReleaseVersion: config.ReleaseVersion,
 
-			APIIP:         config.APIIP,
-			CertTTL:       config.CertTTL,
-			ClusterDomain: config.ClusterDomain,
-			Provider:      config.Provider,
+			CertTTL:            config.CertTTL,
+			ClusterDomain:      config.ClusterDomain,
+			KubeConfigProfiles: config.KubeConfigProfiles,
+			Provider:           config.Provider,
 		}
 
 		certConfigResource, err = certconfig.New(c)
@@ -233,17 +316,90 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 		}
 	}
 
+	var componentsHealthyResource resource.Interface
+	{
+		c := componentshealthy.Config{
+			Event:        config.Event,
+			K8sClient:    config.K8sClient,
+			Logger:       config.Logger,
+			TenantClient: tenantClient,
+
+			Probes: config.HealthProbes,
+		}
+
+		componentsHealthyResource, err = componentshealthy.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	deletionPhaseState := deletionphase.NewState()
+
+	var deletionPhaseResource resource.Interface
+	{
+		c := deletionphase.Config{
+			Event:     config.Event,
+			K8sClient: config.K8sClient,
+			Logger:    config.Logger,
+			State:     deletionPhaseState,
+
+			Timeouts: config.DeletionPhaseTimeouts,
+		}
+
+		deletionPhaseResource, err = deletionphase.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var deletionProtectionResource resource.Interface
+	{
+		c := deletionprotection.Config{
+			Event:     config.Event,
+			K8sClient: config.K8sClient,
+			Logger:    config.Logger,
+		}
+
+		deletionProtectionResource, err = deletionprotection.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var podCIDRAllocationResource resource.Interface
+	{
+		c := podcidrallocation.Config{
+			Event:     config.Event,
+			K8sClient: config.K8sClient,
+			Logger:    config.Logger,
+			PodCIDR:   config.PodCIDR,
+		}
+
+		podCIDRAllocationResource, err = podcidrallocation.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var clusterConfigMapGetter configmapresource.StateGetter
 	{
 		c := clusterconfigmap.Config{
-			BaseDomain: config.BaseDomain,
-			K8sClient:  config.K8sClient.K8sClient(),
-			Logger:     config.Logger,
-			PodCIDR:    config.PodCIDR,
+			BaseDomain:     config.BaseDomain,
+			ClusterIP:      config.ClusterIP,
+			Event:          config.Event,
+			HAMaster:       haMaster,
+			K8sClient:      config.K8sClient,
+			Logger:         config.Logger,
+			PodCIDR:        config.PodCIDR,
+			ReleaseVersion: config.ReleaseVersion,
 
-			ClusterIPRange: config.ClusterIPRange,
-			DNSIP:          config.DNSIP,
-			Provider:       config.Provider,
+			ClusterDomain:              config.ClusterDomain,
+			ClusterValuesTemplate:      config.ClusterValuesTemplate,
+			IngressProviderDefaults:    config.IngressProviderDefaults,
+			NewCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
+			Provider:                   config.Provider,
+			RegistryDomain:             config.RegistryDomain,
+			RegistryMirrors:            config.RegistryMirrors,
 		}
 
 		clusterConfigMapGetter, err = clusterconfigmap.New(c)
@@ -273,6 +429,44 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 		}
 	}
 
+	var registryPullSecretGetter secretresource.StateGetter
+	{
+		c := registrypullsecret.Config{
+			K8sClient: config.K8sClient.K8sClient(),
+			Logger:    config.Logger,
+
+			PullSecretName:      config.RegistryPullSecretName,
+			PullSecretNamespace: config.RegistryPullSecretNamespace,
+			RegistryDomain:      config.RegistryDomain,
+		}
+
+		registryPullSecretGetter, err = registrypullsecret.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var registryPullSecretResource resource.Interface
+	{
+		c := secretresource.Config{
+			K8sClient: config.K8sClient.K8sClient(),
+			Logger:    config.Logger,
+
+			Name:        registrypullsecret.Name,
+			StateGetter: registryPullSecretGetter,
+		}
+
+		ops, err := secretresource.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		registryPullSecretResource, err = toCRUDResource(config.Logger, ops)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var clusterIDResource resource.Interface
 	{
 		c := clusterid.Config{
@@ -407,8 +601,9 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 	var keepForG8sControlPlaneCRsResource resource.Interface
 	{
 		c := keepforcrs.Config{
-			K8sClient: config.K8sClient,
-			Logger:    config.Logger,
+			DeletionBlocker: deletionBlocker,
+			K8sClient:       config.K8sClient,
+			Logger:          config.Logger,
 
 			NewObjFunc: func() runtime.Object {
 				return &infrastructurev1alpha2.G8sControlPlane{}
@@ -424,8 +619,9 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 	var keepForMachineDeploymentCRsResource resource.Interface
 	{
 		c := keepforcrs.Config{
-			K8sClient: config.K8sClient,
-			Logger:    config.Logger,
+			DeletionBlocker: deletionBlocker,
+			K8sClient:       config.K8sClient,
+			Logger:          config.Logger,
 
 			NewObjFunc: func() runtime.Object {
 				return &apiv1alpha2.MachineDeployment{}
@@ -441,8 +637,9 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 	var keepForInfraRefsResource resource.Interface
 	{
 		c := keepforinfrarefs.Config{
-			K8sClient: config.K8sClient,
-			Logger:    config.Logger,
+			DeletionBlocker: deletionBlocker,
+			K8sClient:       config.K8sClient,
+			Logger:          config.Logger,
 
 			ToObjRef: toClusterObjRef,
 		}
@@ -473,9 +670,13 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 		c := kubeconfig.Config{
 			BaseDomain:    config.BaseDomain,
 			CertsSearcher: config.CertsSearcher,
+			Event:         config.Event,
 			K8sClient:     config.K8sClient.K8sClient(),
 			Logger:        config.Logger,
 			Tenant:        tenantCluster,
+
+			CAPISecret: config.KubeConfigCAPISecret,
+			Profiles:   config.KubeConfigProfiles,
 		}
 
 		kubeConfigGetter, err = kubeconfig.New(c)
@@ -494,17 +695,47 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 			StateGetter: kubeConfigGetter,
 		}
 
-		ops, err := secretresource.New(c)
+		secretResource, err := secretresource.New(c)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
 
+		var ops *kubeconfig.CRUD
+		{
+			c := kubeconfig.CRUDConfig{
+				CRUD:      secretResource,
+				Event:     config.Event,
+				K8sClient: config.K8sClient.K8sClient(),
+				Logger:    config.Logger,
+			}
+
+			ops, err = kubeconfig.NewCRUD(c)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+		}
+
 		kubeConfigResource, err = toCRUDResource(config.Logger, ops)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
 	}
 
+	var kubeConfigRBACResource resource.Interface
+	{
+		c := kubeconfigrbac.Config{
+			Logger:       config.Logger,
+			TenantClient: tenantClient,
+
+			Profiles: config.KubeConfigProfiles,
+		}
+
+		kubeConfigRBACResource, err = kubeconfigrbac.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var statusConditionResource resource.Interface
 	{
 		c := statuscondition.Config{
@@ -514,8 +745,10 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 			ReleaseVersion: config.ReleaseVersion,
 			TenantClient:   tenantClient,
 
+			CreationThreshold:          config.DegradedCreationThreshold,
 			NewCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
 			Provider:                   config.Provider,
+			UpdateThreshold:            config.DegradedUpdateThreshold,
 		}
 
 		statusConditionResource, err = statuscondition.New(c)
@@ -524,6 +757,23 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 		}
 	}
 
+	var tenantCleanupResource resource.Interface
+	{
+		c := tenantcleanup.Config{
+			Event:        config.Event,
+			K8sClient:    config.K8sClient,
+			Logger:       config.Logger,
+			TenantClient: tenantClient,
+
+			Timeout: config.TenantCleanupTimeout,
+		}
+
+		tenantCleanupResource, err = tenantcleanup.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var updateG8sControlPlanesResource resource.Interface
 	{
 		c := updateg8scontrolplanes.Config{
@@ -568,11 +818,25 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 	}
 
 	resources := []resource.Interface{
+		// Following resource must run first, because it prevents the deletion of
+		// protected tenant clusters by canceling the reconciliation.
+		deletionProtectionResource,
+
+		// Following resource must run before any resource deleting anything,
+		// because it decides which deletion phase the gated resources run in.
+		deletionPhaseResource,
+
+		// Following resource cleans up cloud resources through the tenant API
+		// during deletion.
+		tenantCleanupResource,
+
 		// Following resources manage resources in the control plane.
 		cpNamespaceResource,
 		encryptionKeyResource,
 		certConfigResource,
+		podCIDRAllocationResource,
 		clusterConfigMapResource,
+		registryPullSecretResource,
 		kubeConfigResource,
 		appResource,
 		appVersionLabelResource,
@@ -580,10 +844,14 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 		updateMachineDeploymentsResource,
 		updateInfraRefsResource,
 
+		// Following resources manage resources in the tenant cluster.
+		kubeConfigRBACResource,
+
 		// Following resources manage CR status information.
 		clusterIDResource,
 		clusterStatusResource,
 		statusConditionResource,
+		componentsHealthyResource,
 
 		// Following resources manage tenant cluster deletion events.
 		deleteG8sControlPlaneCRsResource,
@@ -594,21 +862,36 @@ func newClusterResources(config ClusterConfig) ([]resource.Interface, error) {
 		keepForInfraRefsResource,
 	}
 
-	// Wrap resources with retry and metrics.
+	// Gate the resources deleting anything by the deletion phase they belong to,
+	// so that the deletion order does not depend on the order of the resources
+	// above.
 	{
-		c := retryresource.WrapConfig{
+		c := deletionphase.WrapConfig{
 			Logger: config.Logger,
-		}
+			Phases: map[resource.Interface]string{
+				tenantCleanupResource: key.DeletionPhaseApps,
+				appResource:           key.DeletionPhaseApps,
 
-		resources, err = retryresource.Wrap(resources, c)
-		if err != nil {
-			return nil, microerror.Mask(err)
+				deleteMachineDeploymentCRsResource:  key.DeletionPhaseNodePools,
+				keepForMachineDeploymentCRsResource: key.DeletionPhaseNodePools,
+
+				deleteG8sControlPlaneCRsResource:  key.DeletionPhaseControlPlane,
+				keepForG8sControlPlaneCRsResource: key.DeletionPhaseControlPlane,
+
+				deleteInfraRefsResource:  key.DeletionPhaseInfrastructure,
+				keepForInfraRefsResource: key.DeletionPhaseInfrastructure,
+
+				cpNamespaceResource:        key.DeletionPhaseManagement,
+				encryptionKeyResource:      key.DeletionPhaseManagement,
+				certConfigResource:         key.DeletionPhaseManagement,
+				clusterConfigMapResource:   key.DeletionPhaseManagement,
+				registryPullSecretResource: key.DeletionPhaseManagement,
+				kubeConfigResource:         key.DeletionPhaseManagement,
+			},
+			State: deletionPhaseState,
 		}
-	}
 
-	{
-		c := metricsresource.WrapConfig{}
-		resources, err = metricsresource.Wrap(resources, c)
+		resources, err = deletionphase.Wrap(resources, c)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+	"sort"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/v4/pkg/resource/crud"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/pkg/project"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/deletionphase"
+	"github.com/giantswarm/cluster-operator/v3/service/deletionpreview"
+)
+
+// Preview runs the deletion logic of the cluster controller's resources in
+// preview mode and returns the objects they would delete in the order they
+// would be deleted, which is the order of the deletion phases. CRUD resources
+// have their delete changes recorded instead of applied, unless their CRUD
+// implementation implements deletionpreview.Previewer. Other resources only
+// take part when they implement deletionpreview.Previewer, because their
+// EnsureDeleted is not free of side effects.
+func (c *Cluster) Preview(ctx context.Context, clusterID string) (deletionpreview.Preview, error) {
+	var cl apiv1alpha2.Cluster
+	{
+		var list apiv1alpha2.ClusterList
+		err := c.k8sClient.CtrlClient().List(
+			ctx,
+			&list,
+			client.MatchingLabels{
+				label.Cluster:         clusterID,
+				label.OperatorVersion: project.Version(),
+			},
+		)
+		if err != nil {
+			return deletionpreview.Preview{}, microerror.Mask(err)
+		}
+
+		if len(list.Items) == 0 {
+			return deletionpreview.Preview{}, deletionpreview.NotFoundError(clusterID)
+		}
+
+		cl = list.Items[0]
+	}
+
+	// Resources behave differently for tenant clusters being deleted, e.g. Apps
+	// are not deleted explicitly because they vanish together with the cluster
+	// namespace. The deletion timestamp is only set on our copy of the Cluster
+	// CR, so that the resources see the tenant cluster as being deleted.
+	if cl.GetDeletionTimestamp() == nil {
+		now := metav1.Now()
+		cl.SetDeletionTimestamp(&now)
+	}
+
+	p := deletionpreview.Preview{
+		ClusterID:         clusterID,
+		DeletionProtected: key.IsDeletionProtected(&cl),
+		Objects:           []deletionpreview.Object{},
+	}
+
+	for _, r := range c.resources {
+		var objects []deletionpreview.Object
+		var err error
+
+		var phase string
+		if g, ok := r.(*deletionphase.Gate); ok {
+			phase = g.Phase()
+			r = g.Wrapped()
+		}
+
+		switch t := r.(type) {
+		case *crud.Resource:
+			if previewer, ok := t.CRUD().(deletionpreview.Previewer); ok {
+				objects, err = previewer.PreviewDeleted(ctx, &cl)
+			} else {
+				objects, err = deletionpreview.PreviewCRUD(ctx, c.logger, t.CRUD(), &cl)
+			}
+		case deletionpreview.Previewer:
+			objects, err = t.PreviewDeleted(ctx, &cl)
+		default:
+			continue
+		}
+		if err != nil {
+			return deletionpreview.Preview{}, microerror.Mask(err)
+		}
+
+		for _, o := range objects {
+			o.Phase = phase
+			o.Resource = r.Name()
+			p.Objects = append(p.Objects, o)
+		}
+	}
+
+	// Objects are deleted phase by phase. Within a phase they are deleted in the
+	// order of the resources.
+	sort.SliceStable(p.Objects, func(i, j int) bool {
+		return key.DeletionPhaseIndex(p.Objects[i].Phase) < key.DeletionPhaseIndex(p.Objects[j].Phase)
+	})
+
+	return p, nil
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giantswarm/k8sclient/v5/pkg/k8sclienttest"
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/giantswarm/operatorkit/v4/pkg/resource"
+	"github.com/giantswarm/operatorkit/v4/pkg/resource/k8s/secretresource"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/pkg/project"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/kubeconfig"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/recorder"
+)
+
+// renewingStateGetter deletes the secret it is asked for, like the kubeconfig
+// state getter does for certificates due for renewal.
+type renewingStateGetter struct {
+	k8sClient *fakek8s.Clientset
+}
+
+func (g *renewingStateGetter) GetCurrentState(ctx context.Context, obj interface{}) ([]*corev1.Secret, error) {
+	s, err := g.k8sClient.CoreV1().Secrets("a2wax").Get(ctx, "a2wax-kubeconfig", metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return []*corev1.Secret{s}, nil
+}
+
+func (g *renewingStateGetter) GetDesiredState(ctx context.Context, obj interface{}) ([]*corev1.Secret, error) {
+	err := g.k8sClient.CoreV1().Secrets("a2wax").Delete(ctx, "a2wax-kubeconfig", metav1.DeleteOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func Test_Cluster_Preview_noWrites(t *testing.T) {
+	var err error
+	ctx := context.Background()
+
+	cl := &apiv1alpha2.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "a2wax",
+			Namespace: "default",
+			Labels: map[string]string{
+				label.Cluster:         "a2wax",
+				label.OperatorVersion: project.Version(),
+			},
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "a2wax-kubeconfig",
+			Namespace: "a2wax",
+		},
+	}
+
+	var k8sClient *k8sclienttest.Clients
+	{
+		scheme := runtime.NewScheme()
+		err = apiv1alpha2.AddToScheme(scheme)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		k8sClient = k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+			CtrlClient: fake.NewFakeClientWithScheme(scheme, cl),
+			K8sClient:  fakek8s.NewSimpleClientset(secret),
+		})
+	}
+
+	var kubeConfigResource resource.Interface
+	{
+		c := secretresource.Config{
+			K8sClient: k8sClient.K8sClient(),
+			Logger:    microloggertest.New(),
+
+			Name:        kubeconfig.Name,
+			StateGetter: &renewingStateGetter{k8sClient: k8sClient.K8sClient().(*fakek8s.Clientset)},
+		}
+
+		secretResource, err := secretresource.New(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var ops *kubeconfig.CRUD
+		{
+			c := kubeconfig.CRUDConfig{
+				CRUD:      secretResource,
+				Event:     recorder.New(recorder.Config{K8sClient: k8sClient}),
+				K8sClient: k8sClient.K8sClient(),
+				Logger:    microloggertest.New(),
+			}
+
+			ops, err = kubeconfig.NewCRUD(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		kubeConfigResource, err = toCRUDResource(microloggertest.New(), ops)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := &Cluster{
+		k8sClient: k8sClient,
+		logger:    microloggertest.New(),
+		resources: []resource.Interface{
+			kubeConfigResource,
+		},
+	}
+
+	p, err := c.Preview(ctx, "a2wax")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(p.Objects) != 0 {
+		t.Fatalf("expected %d to be equal to %d", 0, len(p.Objects))
+	}
+
+	for _, a := range k8sClient.K8sClient().(*fakek8s.Clientset).Actions() {
+		if a.GetVerb() != "get" && a.GetVerb() != "list" && a.GetVerb() != "watch" {
+			t.Fatalf("expected no writes, got %s of %s", a.GetVerb(), a.GetResource().Resource)
+		}
+	}
+}
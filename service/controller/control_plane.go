@@ -23,6 +23,7 @@ import (
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/updateinfrarefs"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/basedomain"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/nodecount"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/reconciliation"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/recorder"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/releaseversion"
 )
@@ -30,13 +31,14 @@ import (
 // ControlPlaneConfig contains necessary dependencies and settings for the
 // ControlPlane controller implementation.
 type ControlPlaneConfig struct {
-	BaseDomain     basedomain.Interface
-	Event          recorder.Interface
-	K8sClient      k8sclient.Interface
-	Logger         micrologger.Logger
-	NodeCount      nodecount.Interface
-	Tenant         tenantcluster.Interface
-	ReleaseVersion releaseversion.Interface
+	BaseDomain            basedomain.Interface
+	Event                 recorder.Interface
+	K8sClient             k8sclient.Interface
+	Logger                micrologger.Logger
+	NodeCount             nodecount.Interface
+	Tenant                tenantcluster.Interface
+	ReconciliationTracker *reconciliation.Tracker
+	ReleaseVersion        releaseversion.Interface
 
 	Provider string
 }
@@ -165,7 +167,7 @@ func newControlPlaneResources(config ControlPlaneConfig) ([]resource.Interface,
 		updateInfraRefsResource,
 	}
 
-	// Wrap resources with retry and metrics.
+	// Wrap resources with retry, metrics and reconciliation tracking.
 	{
 		c := retryresource.WrapConfig{
 			Logger: config.Logger,
@@ -185,6 +187,19 @@ func newControlPlaneResources(config ControlPlaneConfig) ([]resource.Interface,
 		}
 	}
 
+	{
+		c := reconciliation.WrapConfig{
+			Tracker: config.ReconciliationTracker,
+
+			Controller: "controlplane",
+		}
+
+		resources, err = reconciliation.Wrap(resources, c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	return resources, nil
 }
 
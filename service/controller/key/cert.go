@@ -2,12 +2,30 @@ package key
 
 import (
 	"fmt"
+	"net"
 )
 
 const (
-	LocalhostIP = "127.0.0.1"
+	LocalhostIP   = "127.0.0.1"
+	LocalhostIPv6 = "::1"
 )
 
+// CertAPIIPSANs returns the IP SANs for Kubernetes API certs given the API
+// service IPs of all IP families the cluster uses.
+func CertAPIIPSANs(apiIPs []string) []string {
+	ipSANs := append([]string{}, apiIPs...)
+	ipSANs = append(ipSANs, LocalhostIP)
+
+	for _, ip := range apiIPs {
+		if net.ParseIP(ip).To4() == nil {
+			ipSANs = append(ipSANs, LocalhostIPv6)
+			break
+		}
+	}
+
+	return ipSANs
+}
+
 // CertDefaultAltNames returns default alt names for Kubernetes API certs.
 func CertDefaultAltNames(clusterDomain string) []string {
 	return []string{
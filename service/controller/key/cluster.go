@@ -2,15 +2,41 @@ package key
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/giantswarm/microerror"
 	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
 )
 
 func APIEndpoint(getter LabelsGetter, base string) string {
 	return fmt.Sprintf("api.%s.k8s.%s", ClusterID(getter), base)
 }
 
+// CAPIKubeConfigSecretName returns the name of the kubeconfig secret Cluster
+// API tooling expects for the given Cluster CR.
+func CAPIKubeConfigSecretName(cr apiv1alpha2.Cluster) string {
+	return fmt.Sprintf("%s-kubeconfig", cr.GetName())
+}
+
+// IsDeletionProtected returns whether the given Cluster CR is protected from
+// being deleted. Values which cannot be parsed as bool protect the Cluster CR,
+// so that typos never lead to a deletion.
+func IsDeletionProtected(getter AnnotationsGetter) bool {
+	v, ok := getter.GetAnnotations()[annotation.DeletionProtection]
+	if !ok {
+		return false
+	}
+
+	protected, err := strconv.ParseBool(v)
+	if err != nil {
+		return true
+	}
+
+	return protected
+}
+
 func KubeConfigEndpoint(getter LabelsGetter, base string) string {
 	return fmt.Sprintf("https://%s", APIEndpoint(getter, base))
 }
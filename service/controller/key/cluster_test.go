@@ -5,7 +5,10 @@ import (
 	"testing"
 
 	"github.com/giantswarm/microerror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
 )
 
 func Test_ToCluster(t *testing.T) {
@@ -56,3 +59,43 @@ func Test_ToCluster(t *testing.T) {
 		})
 	}
 }
+
+func Test_IsDeletionProtected(t *testing.T) {
+	testCases := []struct {
+		description       string
+		annotations       map[string]string
+		expectedProtected bool
+	}{
+		{
+			description:       "missing annotation does not protect",
+			annotations:       nil,
+			expectedProtected: false,
+		},
+		{
+			description:       "true protects",
+			annotations:       map[string]string{annotation.DeletionProtection: "true"},
+			expectedProtected: true,
+		},
+		{
+			description:       "false does not protect",
+			annotations:       map[string]string{annotation.DeletionProtection: "false"},
+			expectedProtected: false,
+		},
+		{
+			description:       "invalid value protects",
+			annotations:       map[string]string{annotation.DeletionProtection: "yes"},
+			expectedProtected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cl := &apiv1alpha2.Cluster{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+
+			protected := IsDeletionProtected(cl)
+			if protected != tc.expectedProtected {
+				t.Fatalf("protected %t doesn't match expected %t", protected, tc.expectedProtected)
+			}
+		})
+	}
+}
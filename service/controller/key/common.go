@@ -40,6 +40,12 @@ func OrganizationID(getter LabelsGetter) string {
 	return getter.GetLabels()[label.Organization]
 }
 
+// RegistryPullSecretName returns the name of the secret holding the image
+// pull secret values of apps of this tenant cluster.
+func RegistryPullSecretName(getter LabelsGetter) string {
+	return fmt.Sprintf("%s-registry-pull-secret", ClusterID(getter))
+}
+
 func ReleaseName(releaseVersion string) string {
 	return fmt.Sprintf("v%s", releaseVersion)
 }
@@ -0,0 +1,57 @@
+package key
+
+import (
+	"encoding/json"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+)
+
+const (
+	HealthProbeKindDaemonSet  = "daemonset"
+	HealthProbeKindDeployment = "deployment"
+	HealthProbeKindReadyz     = "readyz"
+)
+
+// ComponentHealth is the result of the health probe of a tenant cluster
+// component.
+type ComponentHealth struct {
+	// Component is the name of the probed component.
+	Component string `json:"component"`
+	// Healthy is true when the probe succeeded.
+	Healthy bool `json:"healthy"`
+	// Message describes the result in a human readable way.
+	Message string `json:"message,omitempty"`
+	// Reason is a machine readable reason for the result, e.g. NotAvailable.
+	Reason string `json:"reason"`
+}
+
+// ComponentHealthFromAnnotation returns the component health results stored in
+// the annotations of the given object. Malformed annotations are treated like
+// missing ones.
+func ComponentHealthFromAnnotation(getter AnnotationsGetter) []ComponentHealth {
+	v, ok := getter.GetAnnotations()[annotation.ComponentHealth]
+	if !ok {
+		return nil
+	}
+
+	var results []ComponentHealth
+	err := json.Unmarshal([]byte(v), &results)
+	if err != nil {
+		return nil
+	}
+
+	return results
+}
+
+// ComponentHealthAnnotation returns the annotation value for the given
+// component health results.
+func ComponentHealthAnnotation(results []ComponentHealth) string {
+	b, err := json.Marshal(results)
+	if err != nil {
+		// Component health results consist of plain strings and bools, which
+		// always marshal.
+		panic(err)
+	}
+
+	return string(b)
+}
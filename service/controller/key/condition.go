@@ -0,0 +1,114 @@
+package key
+
+import (
+	"encoding/json"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiv1alpha3 "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+)
+
+const (
+	// ComponentsHealthyCondition is true when all probed tenant cluster
+	// components are healthy. The results per component are stored in the
+	// component health annotation.
+	ComponentsHealthyCondition apiv1alpha3.ConditionType = "ComponentsHealthy"
+
+	// DeletionBlockedCondition is true when the deletion of a tenant cluster
+	// is blocked by its deletion protection.
+	DeletionBlockedCondition apiv1alpha3.ConditionType = "DeletionBlocked"
+
+	// DegradedCondition is true when the creation or the update of a tenant
+	// cluster did not finish within the configured threshold. The reason names
+	// the first problem found.
+	DegradedCondition apiv1alpha3.ConditionType = "Degraded"
+
+	// KubernetesVersionSkewedCondition is true when the tenant API server or any
+	// kubelet of a tenant cluster reports another Kubernetes version than the
+	// one of the release of the tenant cluster.
+	KubernetesVersionSkewedCondition apiv1alpha3.ConditionType = "KubernetesVersionSkewed"
+
+	// PodCIDROverlappingCondition is true when the custom pod CIDR of a tenant
+	// cluster overlaps the installation pod CIDR or the pod CIDR of another
+	// tenant cluster.
+	PodCIDROverlappingCondition apiv1alpha3.ConditionType = "PodCIDROverlapping"
+)
+
+// Condition returns the condition of the given type, or nil if there is no
+// such condition.
+func Condition(conditions apiv1alpha3.Conditions, t apiv1alpha3.ConditionType) *apiv1alpha3.Condition {
+	for i := range conditions {
+		if conditions[i].Type == t {
+			return &conditions[i]
+		}
+	}
+
+	return nil
+}
+
+// Conditions returns the conditions stored in the annotations of the given
+// object. Malformed annotations are treated like missing ones, so that they are
+// overwritten with the next observation.
+func Conditions(getter AnnotationsGetter) apiv1alpha3.Conditions {
+	v, ok := getter.GetAnnotations()[annotation.Conditions]
+	if !ok {
+		return nil
+	}
+
+	var conditions apiv1alpha3.Conditions
+	err := json.Unmarshal([]byte(v), &conditions)
+	if err != nil {
+		return nil
+	}
+
+	return conditions
+}
+
+// ConditionsAnnotation returns the annotation value for the given conditions.
+func ConditionsAnnotation(conditions apiv1alpha3.Conditions) string {
+	b, err := json.Marshal(conditions)
+	if err != nil {
+		// Conditions consist of plain strings and timestamps, which always
+		// marshal.
+		panic(err)
+	}
+
+	return string(b)
+}
+
+// IsConditionTrue returns whether the condition of the given type is true.
+func IsConditionTrue(conditions apiv1alpha3.Conditions, t apiv1alpha3.ConditionType) bool {
+	c := Condition(conditions, t)
+	return c != nil && c.Status == corev1.ConditionTrue
+}
+
+// WithCondition returns the given conditions with the given condition set. The
+// last transition time is only updated when the status changes. The returned
+// bool is true when the conditions changed.
+func WithCondition(conditions apiv1alpha3.Conditions, c apiv1alpha3.Condition) (apiv1alpha3.Conditions, bool) {
+	var result apiv1alpha3.Conditions
+	result = append(result, conditions...)
+
+	current := Condition(result, c.Type)
+	if current == nil {
+		c.LastTransitionTime = metav1.NewTime(time.Now().UTC().Truncate(time.Second))
+		return append(result, c), true
+	}
+
+	if current.Status == c.Status {
+		c.LastTransitionTime = current.LastTransitionTime
+	} else {
+		c.LastTransitionTime = metav1.NewTime(time.Now().UTC().Truncate(time.Second))
+	}
+
+	if *current == c {
+		return result, false
+	}
+
+	*current = c
+
+	return result, true
+}
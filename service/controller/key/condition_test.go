@@ -0,0 +1,115 @@
+package key
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiv1alpha3 "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+)
+
+func Test_Conditions(t *testing.T) {
+	then := metav1.NewTime(time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC))
+
+	testCases := []struct {
+		description           string
+		annotations           map[string]string
+		condition             apiv1alpha3.Condition
+		expectedChanged       bool
+		expectedTrue          bool
+		expectedTransitionSet bool
+	}{
+		{
+			description: "missing annotation",
+			annotations: nil,
+			condition: apiv1alpha3.Condition{
+				Type:   PodCIDROverlappingCondition,
+				Status: corev1.ConditionFalse,
+			},
+			expectedChanged:       true,
+			expectedTrue:          false,
+			expectedTransitionSet: true,
+		},
+		{
+			description: "malformed annotation",
+			annotations: map[string]string{
+				annotation.Conditions: "{",
+			},
+			condition: apiv1alpha3.Condition{
+				Type:   PodCIDROverlappingCondition,
+				Status: corev1.ConditionTrue,
+			},
+			expectedChanged:       true,
+			expectedTrue:          true,
+			expectedTransitionSet: true,
+		},
+		{
+			description: "unchanged condition keeps transition time",
+			annotations: map[string]string{
+				annotation.Conditions: ConditionsAnnotation(apiv1alpha3.Conditions{
+					{Type: PodCIDROverlappingCondition, Status: corev1.ConditionFalse, LastTransitionTime: then},
+				}),
+			},
+			condition: apiv1alpha3.Condition{
+				Type:   PodCIDROverlappingCondition,
+				Status: corev1.ConditionFalse,
+			},
+			expectedChanged:       false,
+			expectedTrue:          false,
+			expectedTransitionSet: false,
+		},
+		{
+			description: "changed message keeps transition time",
+			annotations: map[string]string{
+				annotation.Conditions: ConditionsAnnotation(apiv1alpha3.Conditions{
+					{Type: PodCIDROverlappingCondition, Status: corev1.ConditionTrue, LastTransitionTime: then, Message: "a"},
+				}),
+			},
+			condition: apiv1alpha3.Condition{
+				Type:    PodCIDROverlappingCondition,
+				Status:  corev1.ConditionTrue,
+				Message: "b",
+			},
+			expectedChanged:       true,
+			expectedTrue:          true,
+			expectedTransitionSet: false,
+		},
+		{
+			description: "changed status updates transition time",
+			annotations: map[string]string{
+				annotation.Conditions: ConditionsAnnotation(apiv1alpha3.Conditions{
+					{Type: PodCIDROverlappingCondition, Status: corev1.ConditionTrue, LastTransitionTime: then},
+				}),
+			},
+			condition: apiv1alpha3.Condition{
+				Type:   PodCIDROverlappingCondition,
+				Status: corev1.ConditionFalse,
+			},
+			expectedChanged:       true,
+			expectedTrue:          false,
+			expectedTransitionSet: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{Annotations: tc.annotations}
+
+			conditions, changed := WithCondition(Conditions(obj), tc.condition)
+			if changed != tc.expectedChanged {
+				t.Fatalf("changed %t doesn't match expected %t", changed, tc.expectedChanged)
+			}
+			if IsConditionTrue(conditions, tc.condition.Type) != tc.expectedTrue {
+				t.Fatalf("expected condition to be %t", tc.expectedTrue)
+			}
+
+			transition := Condition(conditions, tc.condition.Type).LastTransitionTime
+			if tc.expectedTransitionSet == transition.Equal(&then) {
+				t.Fatalf("unexpected transition time %s", transition)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package key
+
+import (
+	"encoding/json"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+)
+
+// DeletionBlocker is an object which still blocks the deletion of a tenant
+// cluster.
+//
+// Only the time the object started blocking the deletion is stored, so that
+// the annotation does not change as long as the blockers do not change. The
+// age of a blocker is derived from it using DeletionBlockerAge.
+type DeletionBlocker struct {
+	// Kind is the kind of the object, e.g. MachineDeployment.
+	Kind string `json:"kind"`
+	// Name is the name of the object.
+	Name string `json:"name"`
+	// Namespace is the namespace of the object.
+	Namespace string `json:"namespace,omitempty"`
+	// Since is the time the object started blocking the deletion. This is the
+	// deletion timestamp of the object, or the time it was reported first when
+	// the deletion of the object was not requested yet.
+	Since metav1.Time `json:"since"`
+}
+
+// DeletionBlockerAge returns the duration the given deletion blocker has been
+// blocking the deletion for at the given time, rounded down to minutes.
+func DeletionBlockerAge(b DeletionBlocker, now time.Time) time.Duration {
+	return now.Sub(b.Since.Time).Truncate(time.Minute)
+}
+
+// DeletionBlockers returns the deletion blockers stored in the annotations of
+// the given object. Malformed annotations are treated like missing ones.
+func DeletionBlockers(getter AnnotationsGetter) []DeletionBlocker {
+	v, ok := getter.GetAnnotations()[annotation.DeletionBlockers]
+	if !ok {
+		return nil
+	}
+
+	var blockers []DeletionBlocker
+	err := json.Unmarshal([]byte(v), &blockers)
+	if err != nil {
+		return nil
+	}
+
+	return blockers
+}
+
+// DeletionBlockersAnnotation returns the annotation value for the given
+// deletion blockers.
+func DeletionBlockersAnnotation(blockers []DeletionBlocker) string {
+	b, err := json.Marshal(blockers)
+	if err != nil {
+		// Deletion blockers consist of plain strings and timestamps, which
+		// always marshal.
+		panic(err)
+	}
+
+	return string(b)
+}
@@ -0,0 +1,92 @@
+package key
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+)
+
+const (
+	// DeletionPhaseApps deletes the workloads of the tenant cluster owning
+	// cloud resources, e.g. load balancers and volumes.
+	DeletionPhaseApps = "Apps"
+	// DeletionPhaseNodePools deletes the node pools of the tenant cluster.
+	DeletionPhaseNodePools = "NodePools"
+	// DeletionPhaseControlPlane deletes the control plane of the tenant
+	// cluster.
+	DeletionPhaseControlPlane = "ControlPlane"
+	// DeletionPhaseInfrastructure deletes the provider specific infrastructure
+	// of the tenant cluster.
+	DeletionPhaseInfrastructure = "Infrastructure"
+	// DeletionPhaseManagement deletes the secrets, config maps, CertConfigs and
+	// the namespace of the tenant cluster in the management cluster.
+	DeletionPhaseManagement = "Management"
+)
+
+// DeletionPhases are the phases of the deletion of a tenant cluster in the
+// order they are run.
+var DeletionPhases = []string{
+	DeletionPhaseApps,
+	DeletionPhaseNodePools,
+	DeletionPhaseControlPlane,
+	DeletionPhaseInfrastructure,
+	DeletionPhaseManagement,
+}
+
+// DeletionPhase is the deletion phase a tenant cluster is in.
+type DeletionPhase struct {
+	// Name is the name of the phase, e.g. NodePools.
+	Name string `json:"name"`
+	// Overrun is true when the phase exceeded its timeout.
+	Overrun bool `json:"overrun,omitempty"`
+	// Since is the time the phase started.
+	Since metav1.Time `json:"since"`
+}
+
+// DeletionPhaseIndex returns the position of the given deletion phase in
+// DeletionPhases, or -1 when the phase is unknown.
+func DeletionPhaseIndex(name string) int {
+	for i, p := range DeletionPhases {
+		if p == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// DeletionPhaseFromAnnotation returns the deletion phase stored in the
+// annotations of the given object. Malformed annotations and unknown phases
+// are treated like missing ones.
+func DeletionPhaseFromAnnotation(getter AnnotationsGetter) (DeletionPhase, bool) {
+	v, ok := getter.GetAnnotations()[annotation.DeletionPhase]
+	if !ok {
+		return DeletionPhase{}, false
+	}
+
+	var p DeletionPhase
+	err := json.Unmarshal([]byte(v), &p)
+	if err != nil {
+		return DeletionPhase{}, false
+	}
+	if DeletionPhaseIndex(p.Name) < 0 {
+		return DeletionPhase{}, false
+	}
+
+	return p, true
+}
+
+// DeletionPhaseAnnotation returns the annotation value for the given deletion
+// phase.
+func DeletionPhaseAnnotation(p DeletionPhase) string {
+	b, err := json.Marshal(p)
+	if err != nil {
+		// Deletion phases consist of plain strings and timestamps, which always
+		// marshal.
+		panic(err)
+	}
+
+	return string(b)
+}
@@ -25,3 +25,21 @@ func ObjRefToNamespacedName(ref corev1.ObjectReference) types.NamespacedName {
 		Namespace: ref.Namespace,
 	}
 }
+
+// LatestClusterStatusCondition returns the most recent of the given cluster
+// status conditions of the given type, or of any type when the type is empty.
+// Other than CommonClusterStatus.LatestCondition it does not reorder the
+// conditions.
+func LatestClusterStatusCondition(conditions []infrastructurev1alpha2.CommonClusterStatusCondition, condition string) infrastructurev1alpha2.CommonClusterStatusCondition {
+	var latest infrastructurev1alpha2.CommonClusterStatusCondition
+	for _, c := range conditions {
+		if condition != "" && c.Condition != condition {
+			continue
+		}
+		if c.LastTransitionTime.After(latest.LastTransitionTime.Time) {
+			latest = c
+		}
+	}
+
+	return latest
+}
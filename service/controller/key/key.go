@@ -3,6 +3,7 @@ package key
 import (
 	"fmt"
 	"net"
+	"strings"
 
 	"github.com/giantswarm/apiextensions/v3/pkg/apis/core/v1alpha1"
 	"github.com/giantswarm/microerror"
@@ -11,11 +12,18 @@ import (
 )
 
 const (
-	// defaultDNSLastOctet is the last octect for the DNS service IP, the first
-	// 3 octets come from the cluster IP range.
+	// defaultDNSLastOctet is the last octect for the DNS service IP, the
+	// remaining octets come from the cluster IP range.
 	defaultDNSLastOctet = 10
 )
 
+const (
+	// IngressControllerUserValuesName is the name of the config map in the
+	// cluster namespace customers use to tune the ingress controller of their
+	// tenant cluster.
+	IngressControllerUserValuesName = "ingress-controller-user-values"
+)
+
 // AppUserConfigMapName returns the name of the user values configmap for the
 // given app spec.
 func AppUserConfigMapName(appSpec AppSpec) string {
@@ -38,25 +46,40 @@ func CertConfigCertOperatorVersion(cr v1alpha1.CertConfig) string {
 	return cr.Labels[label.CertOperatorVersion]
 }
 
-// DNSIP returns the IP of the DNS service given a cluster IP range.
+// DNSIP returns the IP of the DNS service given a cluster IP range. Both IPv4
+// and IPv6 ranges are supported.
 func DNSIP(clusterIPRange string) (string, error) {
 	ip, _, err := net.ParseCIDR(clusterIPRange)
 	if err != nil {
 		return "", microerror.Maskf(invalidConfigError, err.Error())
 	}
 
-	// Only IPV4 CIDRs are supported.
-	ip = ip.To4()
-	if ip == nil {
-		return "", microerror.Mask(invalidConfigError)
+	if ip.To4() != nil {
+		ip = ip.To4()
 	}
 
 	// IP must be a network address.
-	if ip[3] != 0 {
+	if ip[len(ip)-1] != 0 {
 		return "", microerror.Mask(invalidConfigError)
 	}
 
-	ip[3] = defaultDNSLastOctet
+	ip[len(ip)-1] = defaultDNSLastOctet
 
 	return ip.String(), nil
 }
+
+// DNSIPs returns the IPs of the DNS service given a comma separated list of
+// cluster IP ranges, one per IP family in case of dual-stack clusters.
+func DNSIPs(clusterIPRanges string) ([]string, error) {
+	var ips []string
+	for _, r := range strings.Split(clusterIPRanges, ",") {
+		ip, err := DNSIP(strings.TrimSpace(r))
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		ips = append(ips, ip)
+	}
+
+	return ips, nil
+}
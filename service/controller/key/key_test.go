@@ -1,6 +1,7 @@
 package key
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/giantswarm/apiextensions/v3/pkg/apis/core/v1alpha1"
@@ -53,6 +54,16 @@ func Test_DNSIP(t *testing.T) {
 			input:       "172.31.0.0/16",
 			expected:    "172.31.0.10",
 		},
+		{
+			description: "IPv6 case, 0 in last octet",
+			input:       "fd00:10:96::/112",
+			expected:    "fd00:10:96::a",
+		},
+		{
+			description:  "error, IPv6 last octet != 0",
+			input:        "fd00:10:96::1/112",
+			errorMatcher: IsInvalidConfig,
+		},
 		{
 			description:  "error, not a CIDR block",
 			input:        "134.200.12.0",
@@ -91,3 +102,54 @@ func Test_DNSIP(t *testing.T) {
 		})
 	}
 }
+
+func Test_DNSIPs(t *testing.T) {
+	testCases := []struct {
+		description  string
+		input        string
+		expected     []string
+		errorMatcher func(error) bool
+	}{
+		{
+			description: "IPv4 single-stack",
+			input:       "172.31.0.0/16",
+			expected:    []string{"172.31.0.10"},
+		},
+		{
+			description: "IPv6 single-stack",
+			input:       "fd00:10:96::/112",
+			expected:    []string{"fd00:10:96::a"},
+		},
+		{
+			description: "dual-stack",
+			input:       "172.31.0.0/16, fd00:10:96::/112",
+			expected:    []string{"172.31.0.10", "fd00:10:96::a"},
+		},
+		{
+			description:  "error, invalid second range",
+			input:        "172.31.0.0/16,fd00:10:96::1/112",
+			errorMatcher: IsInvalidConfig,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			actual, err := DNSIPs(tc.input)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("DNSIPs %#v doesn't match expected %#v", actual, tc.expected)
+			}
+		})
+	}
+}
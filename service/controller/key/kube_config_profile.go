@@ -0,0 +1,35 @@
+package key
+
+import (
+	"fmt"
+
+	"github.com/giantswarm/certs/v3/pkg/certs"
+)
+
+// KubeConfigProfileCert returns the certificate used for the kubeconfig of the
+// given profile. It is used as cluster component of the profile's CertConfig.
+func KubeConfigProfileCert(p KubeConfigProfile) certs.Cert {
+	return certs.Cert(fmt.Sprintf("kubeconfig-%s", p.Name))
+}
+
+// KubeConfigProfileGroups returns the groups put into the certificate of the
+// given profile.
+func KubeConfigProfileGroups(p KubeConfigProfile) []string {
+	if len(p.Groups) != 0 {
+		return p.Groups
+	}
+
+	return []string{fmt.Sprintf("giantswarm:kubeconfig:%s", p.Name)}
+}
+
+// KubeConfigProfileRBACName returns the name of the ClusterRoleBinding or
+// RoleBindings managed in the tenant cluster for the given profile.
+func KubeConfigProfileRBACName(p KubeConfigProfile) string {
+	return fmt.Sprintf("giantswarm-kubeconfig-%s", p.Name)
+}
+
+// KubeConfigProfileSecretName returns the name of the kubeconfig secret of the
+// given profile.
+func KubeConfigProfileSecretName(getter LabelsGetter, p KubeConfigProfile) string {
+	return fmt.Sprintf("%s-kubeconfig-%s", ClusterID(getter), p.Name)
+}
@@ -0,0 +1,56 @@
+package key
+
+import (
+	"encoding/json"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+)
+
+// KubernetesVersion holds the Kubernetes versions a tenant cluster reports and
+// their skew against the Kubernetes version of its release.
+type KubernetesVersion struct {
+	// Desired is the Kubernetes version of the release of the tenant cluster.
+	Desired string `json:"desired"`
+	// Kubelets maps the kubelet versions of the nodes of the tenant cluster to
+	// the number of nodes running them.
+	Kubelets map[string]int `json:"kubelets,omitempty"`
+	// KubeletMinorSkew is the largest number of minor versions any kubelet is
+	// behind the desired version. It is negative when kubelets are ahead.
+	KubeletMinorSkew int `json:"kubeletMinorSkew"`
+	// Server is the version reported by the tenant API server.
+	Server string `json:"server,omitempty"`
+	// ServerMinorSkew is the number of minor versions the tenant API server is
+	// behind the desired version. It is negative when the server is ahead.
+	ServerMinorSkew int `json:"serverMinorSkew"`
+}
+
+// KubernetesVersionFromAnnotation returns the Kubernetes versions stored in the
+// annotations of the given object. The returned bool is false when the
+// annotation is missing or malformed.
+func KubernetesVersionFromAnnotation(getter AnnotationsGetter) (KubernetesVersion, bool) {
+	v, ok := getter.GetAnnotations()[annotation.KubernetesVersion]
+	if !ok {
+		return KubernetesVersion{}, false
+	}
+
+	var kv KubernetesVersion
+	err := json.Unmarshal([]byte(v), &kv)
+	if err != nil {
+		return KubernetesVersion{}, false
+	}
+
+	return kv, true
+}
+
+// KubernetesVersionAnnotation returns the annotation value for the given
+// Kubernetes versions.
+func KubernetesVersionAnnotation(kv KubernetesVersion) string {
+	b, err := json.Marshal(kv)
+	if err != nil {
+		// Kubernetes versions consist of plain strings and numbers, which
+		// always marshal.
+		panic(err)
+	}
+
+	return string(b)
+}
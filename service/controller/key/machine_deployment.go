@@ -19,3 +19,16 @@ func ToMachineDeployment(v interface{}) (apiv1alpha2.MachineDeployment, error) {
 
 	return *c, nil
 }
+
+// NodePoolCount returns the number of node pools the given MachineDeployment
+// CRs make up. MachineDeployment CRs being deleted are not counted.
+func NodePoolCount(mds []apiv1alpha2.MachineDeployment) int {
+	var n int
+	for _, md := range mds {
+		if md.GetDeletionTimestamp() == nil {
+			n++
+		}
+	}
+
+	return n
+}
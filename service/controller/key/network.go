@@ -0,0 +1,109 @@
+package key
+
+import (
+	"net"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+)
+
+const (
+	// apiServerIPLastOctet is the last octet for the API server service IP,
+	// the remaining octets come from the cluster IP range.
+	apiServerIPLastOctet = 1
+)
+
+// ParseClusterIPRange returns the network IP and the API server IP of the
+// given cluster IP range. Both IPv4 and IPv6 ranges are supported.
+func ParseClusterIPRange(ipRange string) (net.IP, net.IP, error) {
+	_, cidr, err := net.ParseCIDR(ipRange)
+	if cidr == nil {
+		return nil, nil, microerror.Maskf(invalidConfigError, "invalid Kubernetes ClusterIPRange '%s': cidr == nil", ipRange)
+	} else if err != nil {
+		return nil, nil, microerror.Maskf(invalidConfigError, "invalid Kubernetes ClusterIPRange '%s': %q", ipRange, err)
+	}
+
+	ones, bits := cidr.Mask.Size()
+	switch bits {
+	case 8 * net.IPv4len:
+		// Node gets /24 from Kubernetes and each POD receives one IP from this
+		// block. Therefore CIDR block must be at least /24.
+		if ones > 24 {
+			return nil, nil, microerror.Maskf(invalidConfigError, "Kubernetes ClusterIPRange CIDR network block must be at least /24")
+		}
+	case 8 * net.IPv6len:
+		// The last octet of the network address is used for the API and DNS
+		// service IPs, so the block must be at least /120. Kubernetes does
+		// not accept IPv6 service ranges larger than /108.
+		if ones > 120 || ones < 108 {
+			return nil, nil, microerror.Maskf(invalidConfigError, "Kubernetes ClusterIPRange IPv6 CIDR network block must be between /108 and /120")
+		}
+	default:
+		return nil, nil, microerror.Maskf(invalidConfigError, "Kubernetes ClusterIPRange CIDR must be an IPv4 or IPv6 range")
+	}
+
+	networkIP := cidr.IP
+
+	apiServerIP := make(net.IP, len(networkIP))
+	copy(apiServerIP, networkIP)
+	apiServerIP[len(apiServerIP)-1] = apiServerIPLastOctet
+
+	return networkIP, apiServerIP, nil
+}
+
+// ParseClusterIPRanges parses the comma separated cluster IP ranges of all IP
+// families the cluster uses. It returns the normalized ranges along with the
+// API server IP of each range.
+func ParseClusterIPRanges(ipRanges string) (string, []string, error) {
+	var ranges []string
+	var apiServerIPs []string
+	for _, r := range strings.Split(ipRanges, ",") {
+		r = strings.TrimSpace(r)
+
+		_, apiServerIP, err := ParseClusterIPRange(r)
+		if err != nil {
+			return "", nil, microerror.Mask(err)
+		}
+
+		// The range is valid at this point. Parsing it again gives us the
+		// network without host bits set.
+		_, cidr, err := net.ParseCIDR(r)
+		if err != nil {
+			return "", nil, microerror.Mask(err)
+		}
+
+		ranges = append(ranges, cidr.String())
+		apiServerIPs = append(apiServerIPs, apiServerIP.String())
+	}
+
+	err := ValidateDualStack(ranges)
+	if err != nil {
+		return "", nil, microerror.Mask(err)
+	}
+
+	return strings.Join(ranges, ","), apiServerIPs, nil
+}
+
+// ValidateDualStack ensures the given CIDRs are valid and contain at most one
+// CIDR per IP family.
+func ValidateDualStack(cidrs []string) error {
+	if len(cidrs) > 2 {
+		return microerror.Maskf(invalidConfigError, "expected at most 2 CIDRs, got %d", len(cidrs))
+	}
+
+	families := map[bool]bool{}
+	for _, c := range cidrs {
+		ip, _, err := net.ParseCIDR(c)
+		if err != nil {
+			return microerror.Maskf(invalidConfigError, "invalid CIDR %#q: %q", c, err)
+		}
+
+		isIPv4 := ip.To4() != nil
+		if families[isIPv4] {
+			return microerror.Maskf(invalidConfigError, "expected at most one CIDR per IP family, got %#q", strings.Join(cidrs, ","))
+		}
+		families[isIPv4] = true
+	}
+
+	return nil
+}
@@ -16,3 +16,33 @@ type AppSpec struct {
 	UseUpgradeForce bool
 	Version         string
 }
+
+// HealthProbe is used to define checks of tenant cluster components feeding
+// the ComponentsHealthy condition.
+type HealthProbe struct {
+	// Kind is the kind of the probe, one of daemonset, deployment or readyz.
+	Kind string `json:"kind"`
+	// Name is the name of the probed DaemonSet or Deployment. It names the
+	// component in the probe results and defaults to apiserver for readyz
+	// probes.
+	Name string `json:"name,omitempty"`
+	// Namespace is the namespace of the probed DaemonSet or Deployment. It
+	// defaults to kube-system.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// KubeConfigProfile is used to define additional kubeconfig secrets issued for
+// tenant clusters next to the admin kubeconfig.
+type KubeConfigProfile struct {
+	// ClusterRole is the tenant cluster ClusterRole the groups of the profile
+	// are bound to. No RBAC is managed in the tenant cluster when it is empty,
+	// e.g. for groups which are already bound by other means.
+	ClusterRole string `json:"clusterRole,omitempty"`
+	// Groups are put into the organizations of the certificate issued for the
+	// profile. They default to giantswarm:kubeconfig:<name>.
+	Groups []string `json:"groups,omitempty"`
+	Name   string   `json:"name"`
+	// Namespaces restricts the binding of the ClusterRole to the given tenant
+	// cluster namespaces. The binding is cluster wide when it is empty.
+	Namespaces []string `json:"namespaces,omitempty"`
+}
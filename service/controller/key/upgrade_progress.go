@@ -0,0 +1,63 @@
+package key
+
+import (
+	"encoding/json"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+)
+
+// UpgradeProgress is the progress of the nodes of a tenant cluster towards the
+// desired version.
+type UpgradeProgress struct {
+	// ControlPlane is the progress of the master nodes.
+	ControlPlane NodeProgress `json:"controlPlane"`
+	// NodePools maps the IDs of the node pools to the progress of their worker
+	// nodes.
+	NodePools map[string]NodeProgress `json:"nodePools,omitempty"`
+	// Version is the desired version nodes are compared against.
+	Version string `json:"version"`
+}
+
+// NodeProgress is the progress of a group of nodes towards the desired
+// version.
+type NodeProgress struct {
+	// Desired is the number of desired nodes.
+	Desired int `json:"desired"`
+	// NewVersion is the number of nodes running the desired version.
+	NewVersion int `json:"newVersion"`
+	// OldVersion is the number of nodes running any other version.
+	OldVersion int `json:"oldVersion"`
+	// Ready is the number of ready nodes.
+	Ready int `json:"ready"`
+}
+
+// UpgradeProgressFromAnnotation returns the upgrade progress stored in the
+// annotations of the given object. The returned bool is false when the
+// annotation is missing or malformed.
+func UpgradeProgressFromAnnotation(getter AnnotationsGetter) (UpgradeProgress, bool) {
+	v, ok := getter.GetAnnotations()[annotation.UpgradeProgress]
+	if !ok {
+		return UpgradeProgress{}, false
+	}
+
+	var p UpgradeProgress
+	err := json.Unmarshal([]byte(v), &p)
+	if err != nil {
+		return UpgradeProgress{}, false
+	}
+
+	return p, true
+}
+
+// UpgradeProgressAnnotation returns the annotation value for the given upgrade
+// progress.
+func UpgradeProgressAnnotation(p UpgradeProgress) string {
+	b, err := json.Marshal(p)
+	if err != nil {
+		// The upgrade progress consists of plain strings and numbers, which
+		// always marshal.
+		panic(err)
+	}
+
+	return string(b)
+}
@@ -20,21 +20,24 @@ import (
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/deleteinfrarefs"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/keepforinfrarefs"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/machinedeploymentstatus"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/updateclusternodepools"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/resource/updateinfrarefs"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/basedomain"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/nodecount"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/reconciliation"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/recorder"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/releaseversion"
 )
 
 type MachineDeploymentConfig struct {
-	BaseDomain     basedomain.Interface
-	Event          recorder.Interface
-	K8sClient      k8sclient.Interface
-	Logger         micrologger.Logger
-	NodeCount      nodecount.Interface
-	Tenant         tenantcluster.Interface
-	ReleaseVersion releaseversion.Interface
+	BaseDomain            basedomain.Interface
+	Event                 recorder.Interface
+	K8sClient             k8sclient.Interface
+	Logger                micrologger.Logger
+	NodeCount             nodecount.Interface
+	Tenant                tenantcluster.Interface
+	ReconciliationTracker *reconciliation.Tracker
+	ReleaseVersion        releaseversion.Interface
 
 	Provider string
 }
@@ -133,6 +136,19 @@ func newMachineDeploymentResources(config MachineDeploymentConfig) ([]resource.I
 		}
 	}
 
+	var updateClusterNodePoolsResource resource.Interface
+	{
+		c := updateclusternodepools.Config{
+			K8sClient: config.K8sClient,
+			Logger:    config.Logger,
+		}
+
+		updateClusterNodePoolsResource, err = updateclusternodepools.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var updateInfraRefsResource resource.Interface
 	{
 		c := updateinfrarefs.Config{
@@ -160,6 +176,7 @@ func newMachineDeploymentResources(config MachineDeploymentConfig) ([]resource.I
 		// Following resources manage resources in the control plane.
 		deleteInfraRefsResource,
 		keepForInfraRefsResource,
+		updateClusterNodePoolsResource,
 		updateInfraRefsResource,
 	}
 
@@ -182,6 +199,19 @@ func newMachineDeploymentResources(config MachineDeploymentConfig) ([]resource.I
 		}
 	}
 
+	{
+		c := reconciliation.WrapConfig{
+			Tracker: config.ReconciliationTracker,
+
+			Controller: "machinedeployment",
+		}
+
+		resources, err = reconciliation.Wrap(resources, c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	return resources, nil
 }
 
@@ -57,11 +57,21 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*g8s
 	}
 	appOperatorVersion := componentVersions[releaseversion.AppOperator]
 
+	// Apps get the registry pull secret as secret values once the
+	// registrypullsecret resource distributed it to the cluster namespace.
+	var secretName string
+	{
+		_, ok := secrets[key.RegistryPullSecretName(&cr)]
+		if ok {
+			secretName = key.RegistryPullSecretName(&cr)
+		}
+	}
+
 	for _, appSpec := range appSpecs {
 		userConfig := newUserConfig(cr, appSpec, configMaps, secrets)
 
 		if !appSpec.LegacyOnly {
-			apps = append(apps, r.newApp(appOperatorVersion, cr, appSpec, userConfig))
+			apps = append(apps, r.newApp(appOperatorVersion, cr, appSpec, secretName, userConfig))
 		}
 	}
 
@@ -132,7 +142,7 @@ func (r *Resource) getUserOverrideConfig(ctx context.Context, cr apiv1alpha2.Clu
 	return u, nil
 }
 
-func (r *Resource) newApp(appOperatorVersion string, cr apiv1alpha2.Cluster, appSpec key.AppSpec, userConfig g8sv1alpha1.AppSpecUserConfig) *g8sv1alpha1.App {
+func (r *Resource) newApp(appOperatorVersion string, cr apiv1alpha2.Cluster, appSpec key.AppSpec, secretName string, userConfig g8sv1alpha1.AppSpecUserConfig) *g8sv1alpha1.App {
 	configMapName := key.ClusterConfigMapName(&cr)
 
 	// Override config map name when specified.
@@ -140,6 +150,14 @@ func (r *Resource) newApp(appOperatorVersion string, cr apiv1alpha2.Cluster, app
 		configMapName = appSpec.ConfigMapName
 	}
 
+	var secretConfig g8sv1alpha1.AppSpecConfigSecret
+	if secretName != "" {
+		secretConfig = g8sv1alpha1.AppSpecConfigSecret{
+			Name:      secretName,
+			Namespace: key.ClusterID(&cr),
+		}
+	}
+
 	return &g8sv1alpha1.App{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "App",
@@ -171,6 +189,7 @@ func (r *Resource) newApp(appOperatorVersion string, cr apiv1alpha2.Cluster, app
 					Name:      configMapName,
 					Namespace: key.ClusterID(&cr),
 				},
+				Secret: secretConfig,
 			},
 
 			KubeConfig: g8sv1alpha1.AppSpecKubeConfig{
@@ -60,10 +60,15 @@ func (r *Resource) getDesiredState(ctx context.Context, obj interface{}) (interf
 		return nil, microerror.Mask(err)
 	}
 
+	apiIPs, err := r.clusterIP.APIIPs(ctx, &cr)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
 	certOperatorVersion := componentVersions[releaseversion.CertOperator]
 	var certConfigs []*corev1alpha1.CertConfig
 	{
-		certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForAPI(ctx, bd, cr)))
+		certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForAPI(ctx, bd, apiIPs, cr)))
 		certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForAppOperator(ctx, bd, cr)))
 		certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForAWSOperator(ctx, bd, cr)))
 		certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForCalico(ctx, bd, cr)))
@@ -85,6 +90,10 @@ func (r *Resource) getDesiredState(ctx context.Context, obj interface{}) (interf
 		if r.provider == label.ProviderKVM {
 			certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForFlanneldEtcdClient(ctx, bd, cr)))
 		}
+
+		for _, p := range r.kubeConfigProfiles {
+			certConfigs = append(certConfigs, newCertConfig(certOperatorVersion, cr, r.newSpecForKubeConfigProfile(ctx, bd, cr, p)))
+		}
 	}
 
 	return certConfigs, nil
@@ -116,7 +125,7 @@ func newCertConfig(certOperatorVersion string, cr apiv1alpha2.Cluster, cert core
 	}
 }
 
-func (r *Resource) newSpecForAPI(ctx context.Context, bd string, cr apiv1alpha2.Cluster) corev1alpha1.CertConfigSpecCert {
+func (r *Resource) newSpecForAPI(ctx context.Context, bd string, apiIPs []string, cr apiv1alpha2.Cluster) corev1alpha1.CertConfigSpecCert {
 	defaultAltNames := key.CertDefaultAltNames(r.clusterDomain)
 	desiredAltNames := append(defaultAltNames,
 		fmt.Sprintf("master.%s", key.ClusterID(&cr)),
@@ -129,7 +138,7 @@ func (r *Resource) newSpecForAPI(ctx context.Context, bd string, cr apiv1alpha2.
 		ClusterComponent: certs.APICert.String(),
 		ClusterID:        key.ClusterID(&cr),
 		CommonName:       fmt.Sprintf("api.%s.k8s.%s", key.ClusterID(&cr), bd),
-		IPSANs:           []string{r.apiIP, key.LocalhostIP},
+		IPSANs:           key.CertAPIIPSANs(apiIPs),
 		Organizations:    []string{"system:masters"},
 		TTL:              r.certTTL,
 	}
@@ -253,6 +262,17 @@ func (r *Resource) newSpecForFlanneldEtcdClient(ctx context.Context, bd string,
 	}
 }
 
+func (r *Resource) newSpecForKubeConfigProfile(ctx context.Context, bd string, cr apiv1alpha2.Cluster, p key.KubeConfigProfile) corev1alpha1.CertConfigSpecCert {
+	return corev1alpha1.CertConfigSpecCert{
+		AllowBareDomains: true,
+		ClusterComponent: key.KubeConfigProfileCert(p).String(),
+		ClusterID:        key.ClusterID(&cr),
+		CommonName:       fmt.Sprintf("%s.%s.k8s.%s", key.KubeConfigProfileCert(p), key.ClusterID(&cr), bd),
+		Organizations:    key.KubeConfigProfileGroups(p),
+		TTL:              r.certTTL,
+	}
+}
+
 func (r *Resource) newSpecForNodeOperator(ctx context.Context, bd string, cr apiv1alpha2.Cluster) corev1alpha1.CertConfigSpecCert {
 	return corev1alpha1.CertConfigSpecCert{
 		AllowBareDomains: true,
@@ -0,0 +1,31 @@
+package certconfig
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/cluster-operator/v3/service/deletionpreview"
+)
+
+// PreviewDeleted returns the delete change EnsureDeleted would apply. The
+// resource is not previewed as CRUD resource because it implements the CRUD
+// primitive itself.
+func (r *Resource) PreviewDeleted(ctx context.Context, obj interface{}) ([]deletionpreview.Object, error) {
+	currentState, err := r.getCurrentState(ctx, obj)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	deleteChange, err := r.newDeleteChangeForDeletePatch(ctx, obj, currentState, nil)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	objects, err := deletionpreview.ObjectsOf(deleteChange)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return objects, nil
+}
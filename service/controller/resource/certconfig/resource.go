@@ -8,6 +8,7 @@ import (
 
 	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/basedomain"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/clusterip"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/hamaster"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/releaseversion"
 )
@@ -27,29 +28,31 @@ const (
 // Config represents the configuration used to create a new cloud config resource.
 type Config struct {
 	BaseDomain     basedomain.Interface
+	ClusterIP      clusterip.Interface
 	G8sClient      versioned.Interface
 	HAMaster       hamaster.Interface
 	Logger         micrologger.Logger
 	ReleaseVersion releaseversion.Interface
 
-	APIIP         string
-	CertTTL       string
-	ClusterDomain string
-	Provider      string
+	CertTTL            string
+	ClusterDomain      string
+	KubeConfigProfiles []key.KubeConfigProfile
+	Provider           string
 }
 
 // Resource implements the cloud config resource.
 type Resource struct {
 	baseDomain     basedomain.Interface
+	clusterIP      clusterip.Interface
 	g8sClient      versioned.Interface
 	haMaster       hamaster.Interface
 	logger         micrologger.Logger
 	releaseVersion releaseversion.Interface
 
-	apiIP         string
-	certTTL       string
-	clusterDomain string
-	provider      string
+	certTTL            string
+	clusterDomain      string
+	kubeConfigProfiles []key.KubeConfigProfile
+	provider           string
 }
 
 // New creates a new configured cloud config resource.
@@ -57,6 +60,9 @@ func New(config Config) (*Resource, error) {
 	if config.BaseDomain == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.BaseDomain must not be empty", config)
 	}
+	if config.ClusterIP == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ClusterIP must not be empty", config)
+	}
 	if config.G8sClient == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.G8sClient must not be empty", config)
 	}
@@ -70,9 +76,6 @@ func New(config Config) (*Resource, error) {
 		return nil, microerror.Maskf(invalidConfigError, "%T.ReleaseVersion must not be empty", config)
 	}
 
-	if config.APIIP == "" {
-		return nil, microerror.Maskf(invalidConfigError, "%T.APIIP must not be empty", config)
-	}
 	if config.CertTTL == "" {
 		return nil, microerror.Maskf(invalidConfigError, "%T.CertTTL must not be empty", config)
 	}
@@ -85,15 +88,16 @@ func New(config Config) (*Resource, error) {
 
 	r := &Resource{
 		baseDomain:     config.BaseDomain,
+		clusterIP:      config.ClusterIP,
 		g8sClient:      config.G8sClient,
 		haMaster:       config.HAMaster,
 		logger:         config.Logger,
 		releaseVersion: config.ReleaseVersion,
 
-		apiIP:         config.APIIP,
-		certTTL:       config.CertTTL,
-		clusterDomain: config.ClusterDomain,
-		provider:      config.Provider,
+		certTTL:            config.CertTTL,
+		clusterDomain:      config.ClusterDomain,
+		kubeConfigProfiles: config.KubeConfigProfiles,
+		provider:           config.Provider,
 	}
 
 	return r, nil
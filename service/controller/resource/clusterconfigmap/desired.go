@@ -3,18 +3,20 @@ package clusterconfigmap
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"strings"
 
 	"github.com/giantswarm/microerror"
 	yaml "gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
 	"github.com/giantswarm/cluster-operator/v3/pkg/label"
 	"github.com/giantswarm/cluster-operator/v3/pkg/project"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/hamaster"
 )
 
 func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*corev1.ConfigMap, error) {
@@ -35,47 +37,68 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 		}
 	}
 
-	// useProxyProtocol is only enabled by default for AWS clusters.
-	var useProxyProtocol bool
+	var clusterIPRange string
 	{
-		if r.provider == "aws" {
-			useProxyProtocol = true
+		clusterIPRange, err = r.clusterIP.ClusterIPRange(ctx, &cr)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var dnsIP string
+	{
+		dnsIPs, err := r.clusterIP.DNSIPs(ctx, &cr)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		dnsIP = strings.Join(dnsIPs, ",")
+	}
+
+	var data templateData
+	{
+		data, err = r.newTemplateData(ctx, cr, bd, podCIDR, clusterIPRange, dnsIP)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var clusterValues map[string]interface{}
+	{
+		clusterValues, err = renderValues(r.clusterValuesTemplate, data)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var ingressControllerValues map[string]interface{}
+	{
+		userValues, err := r.getIngressControllerUserValues(ctx, cr)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		managedValues := map[string]interface{}{
+			"baseDomain": key.TenantEndpoint(&cr, bd),
+			"clusterID":  key.ClusterID(&cr),
+		}
+		if data.Proxy.Enabled() {
+			managedValues["proxy"] = data.Proxy.Values()
 		}
+
+		ingressControllerValues = newIngressControllerValues(ingressControllerDefaults(r.provider, r.ingressProviderDefaults), userValues, managedValues)
 	}
 
 	configMapSpecs := []configMapSpec{
 		{
 			Name:      key.ClusterConfigMapName(&cr),
 			Namespace: key.ClusterID(&cr),
-			Values: map[string]interface{}{
-				"baseDomain": key.TenantEndpoint(&cr, bd),
-				"cluster": map[string]interface{}{
-					"calico": map[string]interface{}{
-						"CIDR": podCIDR,
-					},
-					"kubernetes": map[string]interface{}{
-						"API": map[string]interface{}{
-							"clusterIPRange": r.clusterIPRange,
-						},
-						"DNS": map[string]interface{}{
-							"IP": r.dnsIP,
-						},
-					},
-				},
-				"clusterDNSIP": r.dnsIP,
-				"clusterID":    key.ClusterID(&cr),
-			},
+			Values:    clusterValues,
 		},
 		{
-			Name:      "ingress-controller-values",
+			Name:      ingressControllerValuesName,
 			Namespace: key.ClusterID(&cr),
-			Values: map[string]interface{}{
-				"baseDomain": key.TenantEndpoint(&cr, bd),
-				"clusterID":  key.ClusterID(&cr),
-				"configmap": map[string]interface{}{
-					"use-proxy-protocol": strconv.FormatBool(useProxyProtocol),
-				},
-			},
+			Values:    ingressControllerValues,
 		},
 	}
 
@@ -93,6 +116,72 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 	return configMaps, nil
 }
 
+func (r *Resource) newTemplateData(ctx context.Context, cr apiv1alpha2.Cluster, bd, podCIDR, clusterIPRange, dnsIP string) (templateData, error) {
+	infrastructureCR := r.newCommonClusterObjectFunc()
+	{
+		err := r.k8sClient.CtrlClient().Get(ctx, key.ObjRefToNamespacedName(key.ObjRefFromCluster(cr)), infrastructureCR)
+		if err != nil {
+			return templateData{}, microerror.Mask(err)
+		}
+	}
+
+	release, err := r.releaseVersion.Release(ctx, &cr)
+	if err != nil {
+		return templateData{}, microerror.Mask(err)
+	}
+
+	componentVersions, err := r.releaseVersion.ComponentVersion(ctx, &cr)
+	if err != nil {
+		return templateData{}, microerror.Mask(err)
+	}
+
+	// The control plane CR might not be available yet right after cluster
+	// creation. The cluster values are updated once it is.
+	haMasters, err := r.haMaster.Enabled(ctx, key.ClusterID(&cr))
+	if hamaster.IsNotFound(err) {
+		haMasters = false
+	} else if err != nil {
+		return templateData{}, microerror.Mask(err)
+	}
+
+	mdList := &apiv1alpha2.MachineDeploymentList{}
+	{
+		err = r.k8sClient.CtrlClient().List(
+			ctx,
+			mdList,
+			client.InNamespace(cr.GetNamespace()),
+			client.MatchingLabels{label.Cluster: key.ClusterID(&cr)},
+		)
+		if err != nil {
+			return templateData{}, microerror.Mask(err)
+		}
+	}
+
+	data := templateData{
+		BaseDomain:        key.TenantEndpoint(&cr, bd),
+		ClusterID:         key.ClusterID(&cr),
+		ClusterIPRange:    clusterIPRange,
+		ComponentVersions: componentVersions,
+		DNSIP:             dnsIP,
+		HAMasters:         haMasters,
+		NodePools:         key.NodePoolCount(mdList.Items),
+		Organization:      key.OrganizationID(&cr),
+		PodCIDR:           podCIDR,
+		Provider:          r.provider,
+		Proxy:             newProxyConfig(infrastructureCR, &cr, clusterIPRange, podCIDR, key.TenantEndpoint(&cr, bd), r.clusterDomain),
+		RegistryDomain:    r.registryDomain,
+		RegistryMirrors:   r.registryMirrors,
+		ReleaseVersion:    key.ReleaseVersion(&cr),
+
+		Cluster:            cr,
+		InfrastructureCR:   infrastructureCR,
+		MachineDeployments: mdList.Items,
+		Release:            release,
+	}
+
+	return data, nil
+}
+
 func newConfigMap(cr apiv1alpha2.Cluster, configMapSpec configMapSpec) (*corev1.ConfigMap, error) {
 	yamlValues, err := yaml.Marshal(configMapSpec.Values)
 	if err != nil {
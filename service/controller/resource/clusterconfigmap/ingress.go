@@ -0,0 +1,147 @@
+package clusterconfigmap
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ghodss/yaml"
+	"github.com/giantswarm/microerror"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+const (
+	ingressControllerValuesName = "ingress-controller-values"
+)
+
+// ingressControllerDefaults returns the default values of the ingress
+// controller app. Only the proxy protocol setting is defaulted unless provider
+// defaults are enabled, which add provider specific service, load balancer
+// and replica defaults.
+func ingressControllerDefaults(provider string, providerDefaults bool) map[string]interface{} {
+	if !providerDefaults {
+		return map[string]interface{}{
+			"configmap": map[string]interface{}{
+				"use-proxy-protocol": strconv.FormatBool(provider == label.ProviderAWS),
+			},
+		}
+	}
+
+	switch provider {
+	case label.ProviderAWS:
+		return map[string]interface{}{
+			"configmap": map[string]interface{}{
+				"use-proxy-protocol": "true",
+			},
+			"controller": map[string]interface{}{
+				"replicaCount": 3,
+				"service": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						"service.beta.kubernetes.io/aws-load-balancer-proxy-protocol": "*",
+					},
+					"type": "LoadBalancer",
+				},
+			},
+		}
+	case label.ProviderAzure:
+		return map[string]interface{}{
+			"configmap": map[string]interface{}{
+				"use-proxy-protocol": "false",
+			},
+			"controller": map[string]interface{}{
+				"replicaCount": 2,
+				"service": map[string]interface{}{
+					"externalTrafficPolicy": "Local",
+					"type":                  "LoadBalancer",
+				},
+			},
+		}
+	case label.ProviderKVM:
+		return map[string]interface{}{
+			"configmap": map[string]interface{}{
+				"use-proxy-protocol": "false",
+			},
+			"controller": map[string]interface{}{
+				"replicaCount": 2,
+				"service": map[string]interface{}{
+					"nodePorts": map[string]interface{}{
+						"http":  30010,
+						"https": 30011,
+					},
+					"type": "NodePort",
+				},
+			},
+		}
+	default:
+		return map[string]interface{}{
+			"configmap": map[string]interface{}{
+				"use-proxy-protocol": "false",
+			},
+		}
+	}
+}
+
+// getIngressControllerUserValues returns the values of the user config map
+// customers use to tune the ingress controller of a tenant cluster, or nil if
+// there is none.
+func (r *Resource) getIngressControllerUserValues(ctx context.Context, cr apiv1alpha2.Cluster) (map[string]interface{}, error) {
+	r.logger.Debugf(ctx, "finding config map %#q in namespace %#q", key.IngressControllerUserValuesName, key.ClusterID(&cr))
+
+	cm, err := r.k8sClient.K8sClient().CoreV1().ConfigMaps(key.ClusterID(&cr)).Get(ctx, key.IngressControllerUserValuesName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		r.logger.Debugf(ctx, "did not find config map %#q in namespace %#q", key.IngressControllerUserValuesName, key.ClusterID(&cr))
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	r.logger.Debugf(ctx, "found config map %#q in namespace %#q", key.IngressControllerUserValuesName, key.ClusterID(&cr))
+
+	values := map[string]interface{}{}
+	err = yaml.Unmarshal([]byte(cm.Data["values"]), &values)
+	if err != nil {
+		// Broken user values must not prevent the managed values from being
+		// updated. The user values are ignored until they are fixed.
+		r.logger.Errorf(ctx, err, "failed to unmarshal the values of config map %#q", key.IngressControllerUserValuesName)
+		r.event.Warn(ctx, &cr, "IngressControllerUserValuesInvalid", fmt.Sprintf("ignoring values of config map %#q: %s", key.IngressControllerUserValuesName, err))
+		return nil, nil
+	}
+
+	return values, nil
+}
+
+// newIngressControllerValues merges the given default, user and managed values
+// of the ingress controller app. Users must not override the values
+// identifying the tenant cluster, so the managed values are merged last.
+func newIngressControllerValues(defaults, user, managed map[string]interface{}) map[string]interface{} {
+	values := mergeValues(defaults, user)
+	values = mergeValues(values, managed)
+
+	return values
+}
+
+// mergeValues merges the given override values into the given base values.
+// Nested objects are merged recursively, everything else is replaced.
+func mergeValues(base, override map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for k, v := range base {
+		merged[k] = v
+	}
+
+	for k, v := range override {
+		b, ok := merged[k].(map[string]interface{})
+		o, isMap := v.(map[string]interface{})
+		if ok && isMap {
+			merged[k] = mergeValues(b, o)
+		} else {
+			merged[k] = v
+		}
+	}
+
+	return merged
+}
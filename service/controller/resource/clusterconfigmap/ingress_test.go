@@ -0,0 +1,167 @@
+package clusterconfigmap
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_mergeValues(t *testing.T) {
+	testCases := []struct {
+		name           string
+		base           map[string]interface{}
+		override       map[string]interface{}
+		expectedValues map[string]interface{}
+	}{
+		{
+			name:     "case 0: no override",
+			base:     ingressControllerDefaults("kvm", true),
+			override: nil,
+			expectedValues: map[string]interface{}{
+				"configmap": map[string]interface{}{
+					"use-proxy-protocol": "false",
+				},
+				"controller": map[string]interface{}{
+					"replicaCount": 2,
+					"service": map[string]interface{}{
+						"nodePorts": map[string]interface{}{
+							"http":  30010,
+							"https": 30011,
+						},
+						"type": "NodePort",
+					},
+				},
+			},
+		},
+		{
+			name: "case 1: nested values are merged",
+			base: ingressControllerDefaults("aws", true),
+			override: map[string]interface{}{
+				"clusterID": "8y5ck",
+				"controller": map[string]interface{}{
+					"replicaCount": float64(5),
+					"service": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							"service.beta.kubernetes.io/aws-load-balancer-internal": "true",
+						},
+					},
+				},
+			},
+			expectedValues: map[string]interface{}{
+				"clusterID": "8y5ck",
+				"configmap": map[string]interface{}{
+					"use-proxy-protocol": "true",
+				},
+				"controller": map[string]interface{}{
+					"replicaCount": float64(5),
+					"service": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							"service.beta.kubernetes.io/aws-load-balancer-internal":       "true",
+							"service.beta.kubernetes.io/aws-load-balancer-proxy-protocol": "*",
+						},
+						"type": "LoadBalancer",
+					},
+				},
+			},
+		},
+		{
+			name: "case 2: objects are replaced by other values",
+			base: ingressControllerDefaults("azure", true),
+			override: map[string]interface{}{
+				"controller": "disabled",
+			},
+			expectedValues: map[string]interface{}{
+				"configmap": map[string]interface{}{
+					"use-proxy-protocol": "false",
+				},
+				"controller": "disabled",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values := mergeValues(tc.base, tc.override)
+
+			if !reflect.DeepEqual(values, tc.expectedValues) {
+				t.Fatalf("values == %#v, want %#v", values, tc.expectedValues)
+			}
+		})
+	}
+}
+
+func Test_newIngressControllerValues(t *testing.T) {
+	testCases := []struct {
+		name             string
+		provider         string
+		providerDefaults bool
+		user             map[string]interface{}
+		expectedValues   map[string]interface{}
+	}{
+		{
+			name:             "case 0: proxy protocol defaults only",
+			provider:         "aws",
+			providerDefaults: false,
+			user:             nil,
+			expectedValues: map[string]interface{}{
+				"baseDomain": "ingress.8y5ck.k8s.gauss.eu-central-1.aws.gigantic.io",
+				"clusterID":  "8y5ck",
+				"configmap": map[string]interface{}{
+					"use-proxy-protocol": "true",
+				},
+			},
+		},
+		{
+			name:             "case 1: proxy protocol defaults only for other providers",
+			provider:         "kvm",
+			providerDefaults: false,
+			user:             nil,
+			expectedValues: map[string]interface{}{
+				"baseDomain": "ingress.8y5ck.k8s.gauss.eu-central-1.aws.gigantic.io",
+				"clusterID":  "8y5ck",
+				"configmap": map[string]interface{}{
+					"use-proxy-protocol": "false",
+				},
+			},
+		},
+		{
+			name:             "case 2: user values override defaults but not managed values",
+			provider:         "azure",
+			providerDefaults: true,
+			user: map[string]interface{}{
+				"clusterID": "other",
+				"controller": map[string]interface{}{
+					"replicaCount": float64(4),
+				},
+			},
+			expectedValues: map[string]interface{}{
+				"baseDomain": "ingress.8y5ck.k8s.gauss.eu-central-1.aws.gigantic.io",
+				"clusterID":  "8y5ck",
+				"configmap": map[string]interface{}{
+					"use-proxy-protocol": "false",
+				},
+				"controller": map[string]interface{}{
+					"replicaCount": float64(4),
+					"service": map[string]interface{}{
+						"externalTrafficPolicy": "Local",
+						"type":                  "LoadBalancer",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			managed := map[string]interface{}{
+				"baseDomain": "ingress.8y5ck.k8s.gauss.eu-central-1.aws.gigantic.io",
+				"clusterID":  "8y5ck",
+			}
+
+			values := newIngressControllerValues(ingressControllerDefaults(tc.provider, tc.providerDefaults), tc.user, managed)
+
+			if !reflect.DeepEqual(values, tc.expectedValues) {
+				t.Fatalf("values == %#v, want %#v", values, tc.expectedValues)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package clusterconfigmap
+
+import (
+	"strings"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+// proxyConfig is the HTTP proxy configuration managed apps of a tenant cluster
+// have to use.
+type proxyConfig struct {
+	// HTTP is the proxy used for HTTP requests.
+	HTTP string
+	// HTTPS is the proxy used for HTTPS requests.
+	HTTPS string
+	// NoProxy is the comma separated list of destinations reached without
+	// proxy.
+	NoProxy string
+}
+
+// Enabled returns true when the tenant cluster has to use a proxy.
+func (p proxyConfig) Enabled() bool {
+	return p.HTTP != "" || p.HTTPS != ""
+}
+
+// Values returns the proxy configuration in the form of app values, or nil if
+// no proxy is used.
+func (p proxyConfig) Values() map[string]interface{} {
+	if !p.Enabled() {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"http":    p.HTTP,
+		"https":   p.HTTPS,
+		"noProxy": p.NoProxy,
+	}
+}
+
+// newProxyConfig computes the proxy configuration of a tenant cluster from the
+// annotations of its infrastructure CR, falling back to the annotations of its
+// Cluster CR. The destinations not to proxy always include the tenant
+// cluster's service and pod networks as well as its base domain and cluster
+// domain.
+func newProxyConfig(infrastructureCR, cluster key.AnnotationsGetter, clusterIPRange, podCIDR, baseDomain, clusterDomain string) proxyConfig {
+	annotationValue := func(name string) string {
+		v := infrastructureCR.GetAnnotations()[name]
+		if v == "" {
+			v = cluster.GetAnnotations()[name]
+		}
+
+		return strings.TrimSpace(v)
+	}
+
+	p := proxyConfig{
+		HTTP:  annotationValue(annotation.HTTPProxy),
+		HTTPS: annotationValue(annotation.HTTPSProxy),
+	}
+
+	if !p.Enabled() {
+		return p
+	}
+
+	var noProxy []string
+	{
+		entries := []string{
+			"localhost",
+			"127.0.0.1",
+			clusterIPRange,
+			podCIDR,
+			baseDomain,
+			clusterDomain,
+			annotationValue(annotation.NoProxy),
+		}
+
+		seen := map[string]bool{}
+		for _, e := range entries {
+			for _, v := range strings.Split(e, ",") {
+				v = strings.TrimSpace(v)
+				if v == "" || seen[v] {
+					continue
+				}
+
+				noProxy = append(noProxy, v)
+				seen[v] = true
+			}
+		}
+	}
+
+	p.NoProxy = strings.Join(noProxy, ",")
+
+	return p
+}
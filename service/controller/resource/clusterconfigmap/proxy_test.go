@@ -0,0 +1,65 @@
+package clusterconfigmap
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+)
+
+func Test_newProxyConfig(t *testing.T) {
+	testCases := []struct {
+		name                      string
+		infrastructureAnnotations map[string]string
+		clusterAnnotations        map[string]string
+		expectedProxyConfig       proxyConfig
+	}{
+		{
+			name:                      "case 0: no proxy",
+			infrastructureAnnotations: nil,
+			clusterAnnotations:        nil,
+			expectedProxyConfig:       proxyConfig{},
+		},
+		{
+			name: "case 1: proxy configured on the infrastructure CR",
+			infrastructureAnnotations: map[string]string{
+				annotation.HTTPProxy:  "http://proxy.example.com:3128",
+				annotation.HTTPSProxy: "http://proxy.example.com:3129",
+			},
+			clusterAnnotations: map[string]string{
+				annotation.HTTPProxy: "http://ignored.example.com:3128",
+			},
+			expectedProxyConfig: proxyConfig{
+				HTTP:    "http://proxy.example.com:3128",
+				HTTPS:   "http://proxy.example.com:3129",
+				NoProxy: "localhost,127.0.0.1,172.31.0.0/16,10.2.0.0/16,8y5ck.k8s.example.com,cluster.local",
+			},
+		},
+		{
+			name: "case 2: proxy configured on the Cluster CR with additional destinations",
+			clusterAnnotations: map[string]string{
+				annotation.HTTPSProxy: "http://proxy.example.com:3128",
+				annotation.NoProxy:    "internal.example.com, 10.2.0.0/16 ,",
+			},
+			expectedProxyConfig: proxyConfig{
+				HTTPS:   "http://proxy.example.com:3128",
+				NoProxy: "localhost,127.0.0.1,172.31.0.0/16,10.2.0.0/16,8y5ck.k8s.example.com,cluster.local,internal.example.com",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			infrastructureCR := &metav1.ObjectMeta{Annotations: tc.infrastructureAnnotations}
+			cluster := &metav1.ObjectMeta{Annotations: tc.clusterAnnotations}
+
+			p := newProxyConfig(infrastructureCR, cluster, "172.31.0.0/16", "10.2.0.0/16", "8y5ck.k8s.example.com", "cluster.local")
+
+			if !reflect.DeepEqual(p, tc.expectedProxyConfig) {
+				t.Fatalf("proxy config == %#v, want %#v", p, tc.expectedProxyConfig)
+			}
+		})
+	}
+}
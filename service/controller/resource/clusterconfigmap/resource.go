@@ -1,12 +1,19 @@
 package clusterconfigmap
 
 import (
+	"text/template"
+
+	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
+	"github.com/giantswarm/k8sclient/v5/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
-	"k8s.io/client-go/kubernetes"
 
 	"github.com/giantswarm/cluster-operator/v3/service/internal/basedomain"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/clusterip"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/hamaster"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/podcidr"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/recorder"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/releaseversion"
 )
 
 const (
@@ -17,26 +24,48 @@ const (
 // Config represents the configuration used to create a new clusterConfigMap
 // resource.
 type Config struct {
-	BaseDomain basedomain.Interface
-	K8sClient  kubernetes.Interface
-	Logger     micrologger.Logger
-	PodCIDR    podcidr.Interface
+	BaseDomain     basedomain.Interface
+	ClusterIP      clusterip.Interface
+	Event          recorder.Interface
+	HAMaster       hamaster.Interface
+	K8sClient      k8sclient.Interface
+	Logger         micrologger.Logger
+	PodCIDR        podcidr.Interface
+	ReleaseVersion releaseversion.Interface
 
-	ClusterIPRange string
-	DNSIP          string
-	Provider       string
+	ClusterDomain string
+	// ClusterValuesTemplate is the Go template the cluster values are rendered
+	// from. The default template is used when empty.
+	ClusterValuesTemplate string
+	// IngressProviderDefaults defines whether to add provider specific service,
+	// load balancer and replica defaults to the ingress controller values. Only
+	// the proxy protocol setting is defaulted when disabled, which is the
+	// legacy behaviour.
+	IngressProviderDefaults    bool
+	NewCommonClusterObjectFunc func() infrastructurev1alpha2.CommonClusterObject
+	Provider                   string
+	RegistryDomain             string
+	RegistryMirrors            []string
 }
 
 // Resource implements the clusterConfigMap resource.
 type Resource struct {
-	baseDomain basedomain.Interface
-	k8sClient  kubernetes.Interface
-	logger     micrologger.Logger
-	podCIDR    podcidr.Interface
+	baseDomain     basedomain.Interface
+	clusterIP      clusterip.Interface
+	event          recorder.Interface
+	haMaster       hamaster.Interface
+	k8sClient      k8sclient.Interface
+	logger         micrologger.Logger
+	podCIDR        podcidr.Interface
+	releaseVersion releaseversion.Interface
 
-	clusterIPRange string
-	dnsIP          string
-	provider       string
+	clusterDomain              string
+	clusterValuesTemplate      *template.Template
+	ingressProviderDefaults    bool
+	newCommonClusterObjectFunc func() infrastructurev1alpha2.CommonClusterObject
+	provider                   string
+	registryDomain             string
+	registryMirrors            []string
 }
 
 // New creates a new configured config map state getter resource managing
@@ -48,6 +77,15 @@ func New(config Config) (*Resource, error) {
 	if config.BaseDomain == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
 	}
+	if config.ClusterIP == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ClusterIP must not be empty", config)
+	}
+	if config.Event == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Event must not be empty", config)
+	}
+	if config.HAMaster == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.HAMaster must not be empty", config)
+	}
 	if config.K8sClient == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
 	}
@@ -57,26 +95,45 @@ func New(config Config) (*Resource, error) {
 	if config.PodCIDR == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.PodCIDR must not be empty", config)
 	}
+	if config.ReleaseVersion == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ReleaseVersion must not be empty", config)
+	}
 
-	if config.ClusterIPRange == "" {
-		return nil, microerror.Maskf(invalidConfigError, "%T.ClusterIPRange must not be empty", config)
+	if config.ClusterDomain == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ClusterDomain must not be empty", config)
 	}
-	if config.DNSIP == "" {
-		return nil, microerror.Maskf(invalidConfigError, "%T.DNSIP must not be empty", config)
+	if config.NewCommonClusterObjectFunc == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.NewCommonClusterObjectFunc must not be empty", config)
 	}
 	if config.Provider == "" {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Provider must not be empty", config)
 	}
+	if config.RegistryDomain == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.RegistryDomain must not be empty", config)
+	}
+
+	clusterValuesTemplate, err := parseTemplate(config.ClusterValuesTemplate)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
 
 	r := &Resource{
-		baseDomain: config.BaseDomain,
-		k8sClient:  config.K8sClient,
-		logger:     config.Logger,
-		podCIDR:    config.PodCIDR,
+		baseDomain:     config.BaseDomain,
+		clusterIP:      config.ClusterIP,
+		event:          config.Event,
+		haMaster:       config.HAMaster,
+		k8sClient:      config.K8sClient,
+		logger:         config.Logger,
+		podCIDR:        config.PodCIDR,
+		releaseVersion: config.ReleaseVersion,
 
-		clusterIPRange: config.ClusterIPRange,
-		dnsIP:          config.DNSIP,
-		provider:       config.Provider,
+		clusterDomain:              config.ClusterDomain,
+		clusterValuesTemplate:      clusterValuesTemplate,
+		ingressProviderDefaults:    config.IngressProviderDefaults,
+		newCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
+		provider:                   config.Provider,
+		registryDomain:             config.RegistryDomain,
+		registryMirrors:            config.RegistryMirrors,
 	}
 
 	return r, nil
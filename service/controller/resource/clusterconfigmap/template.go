@@ -0,0 +1,140 @@
+package clusterconfigmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"text/template"
+
+	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
+	releasev1alpha1 "github.com/giantswarm/apiextensions/v3/pkg/apis/release/v1alpha1"
+	"github.com/giantswarm/microerror"
+	yaml "gopkg.in/yaml.v2"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+)
+
+// defaultClusterValuesTemplate renders the cluster values every app of a
+// tenant cluster receives, unless the operator is configured with a custom
+// template.
+const defaultClusterValuesTemplate = `baseDomain: {{ .BaseDomain | quote }}
+cluster:
+  calico:
+    CIDR: {{ .PodCIDR | quote }}
+  kubernetes:
+    API:
+      clusterIPRange: {{ .ClusterIPRange | quote }}
+    DNS:
+      IP: {{ .DNSIP | quote }}
+  metadata:
+    haMasters: {{ .HAMasters }}
+    nodePools: {{ .NodePools }}
+    organization: {{ .Organization | quote }}
+    provider: {{ .Provider | quote }}
+    release:
+      components: {{ toJson .ComponentVersions }}
+      version: {{ .ReleaseVersion | quote }}
+clusterDNSIP: {{ .DNSIP | quote }}
+clusterID: {{ .ClusterID | quote }}
+{{- if .Proxy.Enabled }}
+proxy:
+  http: {{ .Proxy.HTTP | quote }}
+  https: {{ .Proxy.HTTPS | quote }}
+  noProxy: {{ .Proxy.NoProxy | quote }}
+{{- end }}
+registry:
+  domain: {{ .RegistryDomain | quote }}
+  mirrors: {{ toJson .RegistryMirrors }}
+`
+
+// templateData is the data cluster values templates are executed with.
+type templateData struct {
+	// BaseDomain is the tenant cluster's base domain including the cluster ID.
+	BaseDomain string
+	// ClusterID is the ID of the tenant cluster.
+	ClusterID string
+	// ClusterIPRange is the comma separated list of service CIDRs.
+	ClusterIPRange string
+	// ComponentVersions maps the components of the tenant cluster's release to
+	// their versions.
+	ComponentVersions map[string]string
+	// DNSIP is the comma separated list of DNS service IPs.
+	DNSIP string
+	// HAMasters is true when the tenant cluster runs more than one master.
+	HAMasters bool
+	// NodePools is the number of node pools of the tenant cluster.
+	NodePools int
+	// Organization is the organization owning the tenant cluster.
+	Organization string
+	// PodCIDR is the comma separated list of pod CIDRs.
+	PodCIDR string
+	// Provider is the provider of the installation, e.g. aws.
+	Provider string
+	// Proxy is the HTTP proxy configuration of the tenant cluster.
+	Proxy proxyConfig
+	// RegistryDomain is the image registry apps pull their images from.
+	RegistryDomain string
+	// RegistryMirrors are the mirrors of the image registry.
+	RegistryMirrors []string
+	// ReleaseVersion is the version of the tenant cluster's release.
+	ReleaseVersion string
+
+	// Cluster is the Cluster CR of the tenant cluster.
+	Cluster apiv1alpha2.Cluster
+	// InfrastructureCR is the provider specific infrastructure CR the Cluster
+	// CR refers to, e.g. the AWSCluster CR.
+	InfrastructureCR infrastructurev1alpha2.CommonClusterObject
+	// MachineDeployments are the MachineDeployment CRs of the tenant cluster's
+	// node pools.
+	MachineDeployments []apiv1alpha2.MachineDeployment
+	// Release is the Release CR of the tenant cluster.
+	Release releasev1alpha1.Release
+}
+
+var templateFuncs = template.FuncMap{
+	"join": func(sep string, elems []string) string {
+		return strings.Join(elems, sep)
+	},
+	"quote": strconv.Quote,
+	"toJson": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+
+		return string(b), nil
+	},
+}
+
+// parseTemplate parses the given cluster values template, falling back to
+// the default template when it is empty.
+func parseTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = defaultClusterValuesTemplate
+	}
+
+	t, err := template.New("cluster-values").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, microerror.Maskf(invalidConfigError, "invalid cluster values template: %s", err)
+	}
+
+	return t, nil
+}
+
+// renderValues executes the given template and returns the resulting values.
+// The rendered content must be a YAML object.
+func renderValues(t *template.Template, data templateData) (map[string]interface{}, error) {
+	var b bytes.Buffer
+	err := t.Execute(&b, data)
+	if err != nil {
+		return nil, microerror.Maskf(executionFailedError, "rendering cluster values template: %s", err)
+	}
+
+	values := map[string]interface{}{}
+	err = yaml.Unmarshal(b.Bytes(), &values)
+	if err != nil {
+		return nil, microerror.Maskf(executionFailedError, "rendered cluster values must be a YAML object: %s", err)
+	}
+
+	return values, nil
+}
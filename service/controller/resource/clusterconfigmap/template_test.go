@@ -0,0 +1,192 @@
+package clusterconfigmap
+
+import (
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+func Test_renderValues(t *testing.T) {
+	data := templateData{
+		BaseDomain:     "8y5ck.k8s.gauss.eu-central-1.aws.gigantic.io",
+		ClusterID:      "8y5ck",
+		ClusterIPRange: "172.31.0.0/16",
+		ComponentVersions: map[string]string{
+			"calico":     "3.15.1",
+			"kubernetes": "1.18.5",
+		},
+		DNSIP:          "172.31.0.10",
+		HAMasters:      true,
+		NodePools:      2,
+		Organization:   "giantswarm",
+		PodCIDR:        "10.2.0.0/16",
+		Provider:       "aws",
+		RegistryDomain: "quay.io",
+		RegistryMirrors: []string{
+			"giantswarm.azurecr.io",
+		},
+		ReleaseVersion: "100.0.0",
+	}
+
+	testCases := []struct {
+		name           string
+		template       string
+		proxy          proxyConfig
+		expectedValues string
+		errorMatcher   func(error) bool
+	}{
+		{
+			name:     "case 0: default template",
+			template: "",
+			expectedValues: `baseDomain: 8y5ck.k8s.gauss.eu-central-1.aws.gigantic.io
+cluster:
+  calico:
+    CIDR: 10.2.0.0/16
+  kubernetes:
+    API:
+      clusterIPRange: 172.31.0.0/16
+    DNS:
+      IP: 172.31.0.10
+  metadata:
+    haMasters: true
+    nodePools: 2
+    organization: giantswarm
+    provider: aws
+    release:
+      components:
+        calico: 3.15.1
+        kubernetes: 1.18.5
+      version: 100.0.0
+clusterDNSIP: 172.31.0.10
+clusterID: 8y5ck
+registry:
+  domain: quay.io
+  mirrors:
+  - giantswarm.azurecr.io
+`,
+			errorMatcher: nil,
+		},
+		{
+			name:     "case 1: default template with proxy",
+			template: "",
+			proxy: proxyConfig{
+				HTTP:    "http://proxy.example.com:3128",
+				HTTPS:   "http://proxy.example.com:3128",
+				NoProxy: "localhost,127.0.0.1",
+			},
+			expectedValues: `baseDomain: 8y5ck.k8s.gauss.eu-central-1.aws.gigantic.io
+cluster:
+  calico:
+    CIDR: 10.2.0.0/16
+  kubernetes:
+    API:
+      clusterIPRange: 172.31.0.0/16
+    DNS:
+      IP: 172.31.0.10
+  metadata:
+    haMasters: true
+    nodePools: 2
+    organization: giantswarm
+    provider: aws
+    release:
+      components:
+        calico: 3.15.1
+        kubernetes: 1.18.5
+      version: 100.0.0
+clusterDNSIP: 172.31.0.10
+clusterID: 8y5ck
+proxy:
+  http: http://proxy.example.com:3128
+  https: http://proxy.example.com:3128
+  noProxy: localhost,127.0.0.1
+registry:
+  domain: quay.io
+  mirrors:
+  - giantswarm.azurecr.io
+`,
+			errorMatcher: nil,
+		},
+		{
+			name: "case 2: template using unknown functions",
+			template: `clusterID: {{ .ClusterID }}
+organization: {{ .Organization | quote }}
+provider:
+  kind: {{ .Provider }}
+release: {{ .ReleaseVersion | quote }}
+zones: {{ toJson (list) }}
+`,
+			expectedValues: "",
+			errorMatcher:   IsInvalidConfig,
+		},
+		{
+			name: "case 3: custom template with functions",
+			template: `clusterID: {{ .ClusterID }}
+organization: {{ .Organization | quote }}
+provider:
+  kind: {{ .Provider }}
+release: {{ .ReleaseVersion | quote }}
+nodePools: {{ len .MachineDeployments }}
+releaseName: {{ toJson .Release.Name }}
+`,
+			expectedValues: `clusterID: 8y5ck
+nodePools: 0
+organization: giantswarm
+provider:
+  kind: aws
+release: 100.0.0
+releaseName: ""
+`,
+			errorMatcher: nil,
+		},
+		{
+			name:           "case 4: template not rendering a YAML object",
+			template:       `{{ .ClusterID }}`,
+			expectedValues: "",
+			errorMatcher:   IsExecutionFailed,
+		},
+		{
+			name:           "case 5: template referring to unknown fields",
+			template:       `clusterID: {{ .Unknown }}`,
+			expectedValues: "",
+			errorMatcher:   IsExecutionFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var values map[string]interface{}
+
+			d := data
+			d.Proxy = tc.proxy
+
+			tmpl, err := parseTemplate(tc.template)
+			if err == nil {
+				values, err = renderValues(tmpl, d)
+			}
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if tc.errorMatcher != nil {
+				return
+			}
+
+			b, err := yaml.Marshal(values)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(b) != tc.expectedValues {
+				t.Fatalf("values == %q, want %q", string(b), tc.expectedValues)
+			}
+		})
+	}
+}
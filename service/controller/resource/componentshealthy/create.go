@@ -0,0 +1,144 @@
+package componentshealthy
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/giantswarm/errors/tenant"
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1alpha3 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/tenantclient"
+)
+
+func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if len(r.probes) == 0 {
+		r.logger.Debugf(ctx, "no health probes configured")
+		r.logger.Debugf(ctx, "canceling resource")
+		return nil
+	}
+
+	tenantClient, err := r.tenantClient.K8sClient(ctx, &cr)
+	if tenantclient.IsNotAvailable(err) {
+		r.logger.Debugf(ctx, "tenant client not available yet")
+		r.logger.Debugf(ctx, "canceling resource")
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var results []key.ComponentHealth
+	{
+		r.logger.Debugf(ctx, "probing %d tenant cluster components", len(r.probes))
+
+		for _, p := range r.probes {
+			result, err := probe(ctx, tenantClient.K8sClient(), p)
+			if tenant.IsAPINotAvailable(err) {
+				// The health of the components is unknown without the tenant API.
+				// The Degraded condition covers unreachable tenant APIs, so we keep
+				// the last observed results.
+				r.logger.Debugf(ctx, "tenant API not available yet")
+				r.logger.Debugf(ctx, "canceling resource")
+				return nil
+			} else if err != nil {
+				return microerror.Mask(err)
+			}
+
+			results = append(results, result)
+		}
+
+		r.logger.Debugf(ctx, "probed %d tenant cluster components", len(r.probes))
+	}
+
+	// Other resources update the conditions of the Cluster CR during the same
+	// reconciliation loop, so we must not patch based on the cached object.
+	{
+		r.logger.Debugf(ctx, "finding latest cluster")
+
+		err = r.k8sClient.CtrlClient().Get(ctx, types.NamespacedName{Name: cr.GetName(), Namespace: cr.GetNamespace()}, &cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "found latest cluster")
+	}
+
+	condition := computeCondition(results)
+
+	wasUnhealthy := key.Condition(key.Conditions(&cr), key.ComponentsHealthyCondition) != nil && !key.IsConditionTrue(key.Conditions(&cr), key.ComponentsHealthyCondition)
+
+	conditions, conditionChanged := key.WithCondition(key.Conditions(&cr), condition)
+	resultsChanged := !reflect.DeepEqual(key.ComponentHealthFromAnnotation(&cr), results)
+	if !conditionChanged && !resultsChanged {
+		r.logger.Debugf(ctx, "condition %#q is up to date", condition.Type)
+		return nil
+	}
+
+	{
+		r.logger.Debugf(ctx, "updating condition %#q", condition.Type)
+
+		patch := client.MergeFrom(cr.DeepCopy())
+
+		a := cr.GetAnnotations()
+		if a == nil {
+			a = map[string]string{}
+		}
+		a[annotation.ComponentHealth] = key.ComponentHealthAnnotation(results)
+		a[annotation.Conditions] = key.ConditionsAnnotation(conditions)
+		cr.SetAnnotations(a)
+
+		err = r.k8sClient.CtrlClient().Patch(ctx, &cr, patch)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "updated condition %#q", condition.Type)
+	}
+
+	if conditionChanged {
+		if condition.Status == corev1.ConditionFalse {
+			r.event.Warn(ctx, &cr, condition.Reason, condition.Message)
+		} else if wasUnhealthy {
+			r.event.Emit(ctx, &cr, "ComponentsRecovered", "all tenant cluster components are healthy")
+		}
+	}
+
+	return nil
+}
+
+// computeCondition returns the ComponentsHealthy condition for the given probe
+// results. The message lists the reasons of all unhealthy components.
+func computeCondition(results []key.ComponentHealth) apiv1alpha3.Condition {
+	var unhealthy []string
+	for _, h := range results {
+		if !h.Healthy {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s: %s", h.Component, h.Reason))
+		}
+	}
+
+	if len(unhealthy) == 0 {
+		return apiv1alpha3.Condition{
+			Type:   key.ComponentsHealthyCondition,
+			Status: corev1.ConditionTrue,
+		}
+	}
+
+	return apiv1alpha3.Condition{
+		Type:    key.ComponentsHealthyCondition,
+		Status:  corev1.ConditionFalse,
+		Reason:  "ComponentsUnhealthy",
+		Message: fmt.Sprintf("%d of %d components are unhealthy: %s", len(unhealthy), len(results), strings.Join(unhealthy, ", ")),
+	}
+}
@@ -0,0 +1,117 @@
+package componentshealthy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/errors/tenant"
+	"github.com/giantswarm/microerror"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+const (
+	reasonHealthy      = "Healthy"
+	reasonNotAvailable = "NotAvailable"
+	reasonNotFound     = "NotFound"
+	reasonNotReady     = "NotReady"
+)
+
+// probe runs the given health probe against the tenant cluster. Errors are
+// only returned when the tenant API could not be asked at all.
+func probe(ctx context.Context, k8sClient kubernetes.Interface, p key.HealthProbe) (key.ComponentHealth, error) {
+	switch p.Kind {
+	case key.HealthProbeKindDaemonSet:
+		return probeDaemonSet(ctx, k8sClient, p)
+	case key.HealthProbeKindDeployment:
+		return probeDeployment(ctx, k8sClient, p)
+	case key.HealthProbeKindReadyz:
+		return probeReadyz(ctx, k8sClient, p)
+	}
+
+	return key.ComponentHealth{}, microerror.Maskf(invalidConfigError, "unknown health probe kind %#q", p.Kind)
+}
+
+func probeDaemonSet(ctx context.Context, k8sClient kubernetes.Interface, p key.HealthProbe) (key.ComponentHealth, error) {
+	ds, err := k8sClient.AppsV1().DaemonSets(probeNamespace(p)).Get(ctx, p.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return unhealthy(p, reasonNotFound, fmt.Sprintf("daemonset %#q not found in namespace %#q", p.Name, probeNamespace(p))), nil
+	} else if err != nil {
+		return key.ComponentHealth{}, microerror.Mask(err)
+	}
+
+	desired := ds.Status.DesiredNumberScheduled
+	available := ds.Status.NumberAvailable
+	if available < desired || ds.Status.UpdatedNumberScheduled < desired {
+		return unhealthy(p, reasonNotAvailable, fmt.Sprintf("%d of %d pods available, %d up to date", available, desired, ds.Status.UpdatedNumberScheduled)), nil
+	}
+
+	return healthy(p), nil
+}
+
+func probeDeployment(ctx context.Context, k8sClient kubernetes.Interface, p key.HealthProbe) (key.ComponentHealth, error) {
+	d, err := k8sClient.AppsV1().Deployments(probeNamespace(p)).Get(ctx, p.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return unhealthy(p, reasonNotFound, fmt.Sprintf("deployment %#q not found in namespace %#q", p.Name, probeNamespace(p))), nil
+	} else if err != nil {
+		return key.ComponentHealth{}, microerror.Mask(err)
+	}
+
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+
+	if d.Status.AvailableReplicas < desired {
+		return unhealthy(p, reasonNotAvailable, fmt.Sprintf("%d of %d replicas available", d.Status.AvailableReplicas, desired)), nil
+	}
+
+	return healthy(p), nil
+}
+
+func probeReadyz(ctx context.Context, k8sClient kubernetes.Interface, p key.HealthProbe) (key.ComponentHealth, error) {
+	_, err := k8sClient.Discovery().RESTClient().Get().AbsPath("/readyz").DoRaw(ctx)
+	if tenant.IsAPINotAvailable(err) {
+		return key.ComponentHealth{}, microerror.Mask(err)
+	} else if err != nil {
+		return unhealthy(p, reasonNotReady, fmt.Sprintf("readyz failed: %s", err)), nil
+	}
+
+	return healthy(p), nil
+}
+
+func probeComponent(p key.HealthProbe) string {
+	if p.Name == "" && p.Kind == key.HealthProbeKindReadyz {
+		return "apiserver"
+	}
+
+	return p.Name
+}
+
+func probeNamespace(p key.HealthProbe) string {
+	if p.Namespace == "" {
+		return "kube-system"
+	}
+
+	return p.Namespace
+}
+
+func healthy(p key.HealthProbe) key.ComponentHealth {
+	return key.ComponentHealth{
+		Component: probeComponent(p),
+		Healthy:   true,
+		Reason:    reasonHealthy,
+	}
+}
+
+func unhealthy(p key.HealthProbe, reason string, message string) key.ComponentHealth {
+	return key.ComponentHealth{
+		Component: probeComponent(p),
+		Healthy:   false,
+		Message:   message,
+		Reason:    reason,
+	}
+}
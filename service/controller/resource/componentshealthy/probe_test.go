@@ -0,0 +1,126 @@
+package componentshealthy
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+func Test_probe(t *testing.T) {
+	replicas := int32(2)
+
+	testCases := []struct {
+		name         string
+		objects      []runtime.Object
+		probe        key.HealthProbe
+		expectResult key.ComponentHealth
+		errorMatcher func(error) bool
+	}{
+		{
+			name: "case 0: available daemonset",
+			objects: []runtime.Object{
+				&appsv1.DaemonSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "calico-node", Namespace: "kube-system"},
+					Status: appsv1.DaemonSetStatus{
+						DesiredNumberScheduled: 3,
+						NumberAvailable:        3,
+						UpdatedNumberScheduled: 3,
+					},
+				},
+			},
+			probe: key.HealthProbe{Kind: key.HealthProbeKindDaemonSet, Name: "calico-node"},
+			expectResult: key.ComponentHealth{
+				Component: "calico-node",
+				Healthy:   true,
+				Reason:    reasonHealthy,
+			},
+		},
+		{
+			name: "case 1: daemonset with unavailable pods",
+			objects: []runtime.Object{
+				&appsv1.DaemonSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "kube-proxy", Namespace: "kube-system"},
+					Status: appsv1.DaemonSetStatus{
+						DesiredNumberScheduled: 3,
+						NumberAvailable:        2,
+						UpdatedNumberScheduled: 3,
+					},
+				},
+			},
+			probe: key.HealthProbe{Kind: key.HealthProbeKindDaemonSet, Name: "kube-proxy"},
+			expectResult: key.ComponentHealth{
+				Component: "kube-proxy",
+				Healthy:   false,
+				Message:   "2 of 3 pods available, 3 up to date",
+				Reason:    reasonNotAvailable,
+			},
+		},
+		{
+			name: "case 2: deployment with unavailable replicas",
+			objects: []runtime.Object{
+				&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"},
+					Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+					Status:     appsv1.DeploymentStatus{AvailableReplicas: 1},
+				},
+			},
+			probe: key.HealthProbe{Kind: key.HealthProbeKindDeployment, Name: "coredns"},
+			expectResult: key.ComponentHealth{
+				Component: "coredns",
+				Healthy:   false,
+				Message:   "1 of 2 replicas available",
+				Reason:    reasonNotAvailable,
+			},
+		},
+		{
+			name:  "case 3: missing deployment",
+			probe: key.HealthProbe{Kind: key.HealthProbeKindDeployment, Name: "coredns", Namespace: "dns"},
+			expectResult: key.ComponentHealth{
+				Component: "coredns",
+				Healthy:   false,
+				Message:   "deployment `coredns` not found in namespace `dns`",
+				Reason:    reasonNotFound,
+			},
+		},
+		{
+			name:         "case 4: unknown probe kind",
+			probe:        key.HealthProbe{Kind: "statefulset", Name: "etcd"},
+			errorMatcher: IsInvalidConfig,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			k8sClient := fake.NewSimpleClientset(tc.objects...)
+
+			result, err := probe(context.Background(), k8sClient, tc.probe)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if tc.errorMatcher != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(result, tc.expectResult) {
+				t.Fatalf("expected %#v to be equal to %#v", tc.expectResult, result)
+			}
+		})
+	}
+}
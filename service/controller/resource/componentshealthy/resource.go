@@ -0,0 +1,68 @@
+package componentshealthy
+
+import (
+	"github.com/giantswarm/k8sclient/v5/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/recorder"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/tenantclient"
+)
+
+const (
+	Name = "componentshealthy"
+)
+
+type Config struct {
+	Event        recorder.Interface
+	K8sClient    k8sclient.Interface
+	Logger       micrologger.Logger
+	TenantClient tenantclient.Interface
+
+	// Probes are the health probes run against the tenant cluster. The
+	// ComponentsHealthy condition is not managed when there are none.
+	Probes []key.HealthProbe
+}
+
+// Resource probes the health of tenant cluster components and reports the
+// results using the ComponentsHealthy condition and the component health
+// annotation of the Cluster CR.
+type Resource struct {
+	event        recorder.Interface
+	k8sClient    k8sclient.Interface
+	logger       micrologger.Logger
+	tenantClient tenantclient.Interface
+
+	probes []key.HealthProbe
+}
+
+func New(config Config) (*Resource, error) {
+	if config.Event == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Event must not be empty", config)
+	}
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.TenantClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.TenantClient must not be empty", config)
+	}
+
+	r := &Resource{
+		event:        config.Event,
+		k8sClient:    config.K8sClient,
+		logger:       config.Logger,
+		tenantClient: config.TenantClient,
+
+		probes: config.Probes,
+	}
+
+	return r, nil
+}
+
+func (r *Resource) Name() string {
+	return Name
+}
@@ -8,6 +8,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/pkg/project"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
 )
 
@@ -26,6 +27,7 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) (interf
 			Name: key.ClusterID(&cr),
 			Labels: map[string]string{
 				label.Cluster:      key.ClusterID(&cr),
+				label.ManagedBy:    project.Name(),
 				label.Organization: key.OrganizationID(&cr),
 			},
 		},
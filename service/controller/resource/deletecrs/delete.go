@@ -6,6 +6,7 @@ import (
 
 	"github.com/giantswarm/microerror"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -20,11 +21,35 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 		return microerror.Mask(err)
 	}
 
+	list, err := r.findObjects(ctx, cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for _, i := range list.Items {
+		i := i // dereferencing pointer value into new scope
+
+		r.logger.Debugf(ctx, "deleting object %#q of type %T for tenant cluster %#q", fmt.Sprintf("%s/%s", i.GetNamespace(), i.GetName()), r.newObjFunc(), key.ClusterID(cr))
+
+		err = r.k8sClient.CtrlClient().Delete(ctx, &i)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "deleted object %#q of type %T for tenant cluster %#q", fmt.Sprintf("%s/%s", i.GetNamespace(), i.GetName()), r.newObjFunc(), key.ClusterID(cr))
+	}
+
+	return nil
+}
+
+// findObjects returns the objects of the configured type belonging to the
+// tenant cluster of the given object.
+func (r *Resource) findObjects(ctx context.Context, cr metav1.Object) (*unstructured.UnstructuredList, error) {
 	var list *unstructured.UnstructuredList
 	{
 		gvk, err := apiutil.GVKForObject(r.newObjFunc(), r.k8sClient.Scheme())
 		if err != nil {
-			return microerror.Mask(err)
+			return nil, microerror.Mask(err)
 		}
 		gvk.Kind += "List"
 
@@ -37,31 +62,18 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 	{
 		r.logger.Debugf(ctx, "finding objects of type %T for tenant cluster %#q", r.newObjFunc(), key.ClusterID(cr))
 
-		err = r.k8sClient.CtrlClient().List(
+		err := r.k8sClient.CtrlClient().List(
 			ctx,
 			list,
 			client.InNamespace(cr.GetNamespace()),
 			client.MatchingLabels{label.Cluster: key.ClusterID(cr)},
 		)
 		if err != nil {
-			return microerror.Mask(err)
+			return nil, microerror.Mask(err)
 		}
 
 		r.logger.Debugf(ctx, "found %d object(s) of type %T for tenant cluster %#q", len(list.Items), r.newObjFunc(), key.ClusterID(cr))
 	}
 
-	for _, i := range list.Items {
-		i := i // dereferencing pointer value into new scope
-
-		r.logger.Debugf(ctx, "deleting object %#q of type %T for tenant cluster %#q", fmt.Sprintf("%s/%s", i.GetNamespace(), i.GetName()), r.newObjFunc(), key.ClusterID(cr))
-
-		err = r.k8sClient.CtrlClient().Delete(ctx, &i)
-		if err != nil {
-			return microerror.Mask(err)
-		}
-
-		r.logger.Debugf(ctx, "deleted object %#q of type %T for tenant cluster %#q", fmt.Sprintf("%s/%s", i.GetNamespace(), i.GetName()), r.newObjFunc(), key.ClusterID(cr))
-	}
-
-	return nil
+	return list, nil
 }
@@ -0,0 +1,29 @@
+package deletecrs
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/giantswarm/cluster-operator/v3/service/deletionpreview"
+)
+
+func (r *Resource) PreviewDeleted(ctx context.Context, obj interface{}) ([]deletionpreview.Object, error) {
+	cr, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	list, err := r.findObjects(ctx, cr)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	objects, err := deletionpreview.ObjectsOf(list.Items)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return objects, nil
+}
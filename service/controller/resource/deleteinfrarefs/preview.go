@@ -0,0 +1,37 @@
+package deleteinfrarefs
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/deletionpreview"
+)
+
+func (r *Resource) PreviewDeleted(ctx context.Context, obj interface{}) ([]deletionpreview.Object, error) {
+	or, err := r.toObjRef(obj)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	ir := &unstructured.Unstructured{}
+	ir.SetAPIVersion(or.APIVersion)
+	ir.SetKind(or.Kind)
+
+	err = r.k8sClient.CtrlClient().Get(ctx, key.ObjRefToNamespacedName(or), ir)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	objects, err := deletionpreview.ObjectsOf(ir)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,99 @@
+package deletionphase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	// The deletion phase may have been advanced by the previous reconciliation
+	// loop, so we must not decide based on the cached object.
+	var cl apiv1alpha2.Cluster
+	{
+		r.logger.Debugf(ctx, "finding latest cluster")
+
+		err = r.k8sClient.CtrlClient().Get(ctx, types.NamespacedName{Name: cr.GetName(), Namespace: cr.GetNamespace()}, &cl)
+		if apierrors.IsNotFound(err) {
+			r.logger.Debugf(ctx, "did not find latest cluster")
+			r.logger.Debugf(ctx, "canceling resource")
+			return nil
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "found latest cluster")
+	}
+
+	now := time.Now()
+
+	current, ok := key.DeletionPhaseFromAnnotation(&cl)
+	if !ok {
+		current = key.DeletionPhase{
+			Name:  key.DeletionPhases[0],
+			Since: metav1.NewTime(now),
+		}
+	}
+
+	updated, completed := advance(current, now, func(phase string) bool {
+		return r.state.Completed(&cl, phase)
+	})
+
+	overrun := !updated.Overrun && now.Sub(updated.Since.Time) > r.timeouts[updated.Name]
+	if overrun {
+		updated.Overrun = true
+	}
+
+	r.state.SetPhase(&cl, updated.Name)
+
+	if ok && updated == current {
+		r.logger.Debugf(ctx, "cluster is in deletion phase %#q", updated.Name)
+		return nil
+	}
+
+	{
+		r.logger.Debugf(ctx, "updating deletion phase to %#q", updated.Name)
+
+		patch := client.MergeFrom(cl.DeepCopy())
+
+		a := cl.GetAnnotations()
+		if a == nil {
+			a = map[string]string{}
+		}
+		a[annotation.DeletionPhase] = key.DeletionPhaseAnnotation(updated)
+		cl.SetAnnotations(a)
+
+		err = r.k8sClient.CtrlClient().Patch(ctx, &cl, patch)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "updated deletion phase to %#q", updated.Name)
+	}
+
+	for _, c := range completed {
+		phaseDuration.WithLabelValues(c.Name).Observe(c.Duration.Seconds())
+		r.event.Emit(ctx, &cl, "DeletionPhaseCompleted", fmt.Sprintf("completed deletion phase %#q after %s", c.Name, c.Duration.Round(time.Second)))
+	}
+
+	if overrun {
+		r.event.Warn(ctx, &cl, "DeletionPhaseOverrun", fmt.Sprintf("deletion phase %#q exceeds its timeout of %s", updated.Name, r.timeouts[updated.Name]))
+	}
+
+	return nil
+}
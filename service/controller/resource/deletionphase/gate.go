@@ -0,0 +1,147 @@
+package deletionphase
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/finalizerskeptcontext"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/reconciliationcanceledcontext"
+	"github.com/giantswarm/operatorkit/v4/pkg/resource"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+type WrapConfig struct {
+	Logger micrologger.Logger
+	// Phases maps resources to the deletion phase their EnsureDeleted runs in.
+	// Resources not mapped run in every phase.
+	Phases map[resource.Interface]string
+	State  *State
+}
+
+// Wrap wraps each resource mapped to a deletion phase with a gate and returns
+// the list of resources in the original order.
+func Wrap(resources []resource.Interface, config WrapConfig) ([]resource.Interface, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.State == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.State must not be empty", config)
+	}
+
+	var wrapped []resource.Interface
+	found := 0
+
+	for _, r := range resources {
+		phase, ok := config.Phases[r]
+		if !ok {
+			wrapped = append(wrapped, r)
+			continue
+		}
+		if key.DeletionPhaseIndex(phase) < 0 {
+			return nil, microerror.Maskf(invalidConfigError, "%T.Phases contains unknown phase %#q for resource %#q", config, phase, r.Name())
+		}
+
+		g := &Gate{
+			id:       config.State.register(phase),
+			logger:   config.Logger,
+			phase:    phase,
+			resource: r,
+			state:    config.State,
+		}
+
+		wrapped = append(wrapped, g)
+		found++
+	}
+
+	if found != len(config.Phases) {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Phases contains resources not being wrapped", config)
+	}
+
+	return wrapped, nil
+}
+
+// Gate runs EnsureDeleted of the wrapped resource only once the tenant cluster
+// reached the deletion phase of the resource and keeps the finalizers until
+// then. It records in the State whether the wrapped resource finished its
+// work, which is the case when it neither keeps the finalizers nor cancels
+// the reconciliation.
+type Gate struct {
+	id       int
+	logger   micrologger.Logger
+	phase    string
+	resource resource.Interface
+	state    *State
+}
+
+func (g *Gate) EnsureCreated(ctx context.Context, obj interface{}) error {
+	err := g.resource.EnsureCreated(ctx, obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (g *Gate) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	cr, err := meta.Accessor(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	current, ok := g.state.Phase(cr)
+	if !ok {
+		current = key.DeletionPhases[0]
+		if p, ok := key.DeletionPhaseFromAnnotation(cr); ok {
+			current = p.Name
+		}
+	}
+
+	if key.DeletionPhaseIndex(current) < key.DeletionPhaseIndex(g.phase) {
+		g.logger.Debugf(ctx, "waiting for deletion phase %#q, current phase is %#q", g.phase, current)
+		g.logger.Debugf(ctx, "keeping finalizers")
+		finalizerskeptcontext.SetKept(ctx)
+		return nil
+	}
+
+	// The wrapped resource gets its own contexts for keeping finalizers and
+	// canceling the reconciliation, so that we can tell whether it finished its
+	// work, regardless of the resources having run before.
+	innerCtx := reconciliationcanceledcontext.NewContext(ctx, make(chan struct{}))
+	innerCtx = finalizerskeptcontext.NewContext(innerCtx, make(chan struct{}))
+
+	err = g.resource.EnsureDeleted(innerCtx, obj)
+	if err != nil {
+		g.state.finish(cr, g.id, false)
+		return microerror.Mask(err)
+	}
+
+	canceled := reconciliationcanceledcontext.IsCanceled(innerCtx)
+	if canceled {
+		reconciliationcanceledcontext.SetCanceled(ctx)
+	}
+	kept := finalizerskeptcontext.IsKept(innerCtx)
+	if kept {
+		finalizerskeptcontext.SetKept(ctx)
+	}
+
+	g.state.finish(cr, g.id, !canceled && !kept)
+
+	return nil
+}
+
+func (g *Gate) Name() string {
+	return g.resource.Name()
+}
+
+// Phase returns the deletion phase the wrapped resource runs in.
+func (g *Gate) Phase() string {
+	return g.phase
+}
+
+// Wrapped returns the wrapped resource.
+func (g *Gate) Wrapped() resource.Interface {
+	return g.resource
+}
@@ -0,0 +1,148 @@
+package deletionphase
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/finalizerskeptcontext"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/reconciliationcanceledcontext"
+	"github.com/giantswarm/operatorkit/v4/pkg/resource"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/unittest"
+)
+
+type fakeResource struct {
+	cancel bool
+	keep   bool
+	ran    bool
+}
+
+func (f *fakeResource) EnsureCreated(ctx context.Context, obj interface{}) error {
+	return nil
+}
+
+func (f *fakeResource) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	f.ran = true
+	if f.cancel {
+		reconciliationcanceledcontext.SetCanceled(ctx)
+	}
+	if f.keep {
+		finalizerskeptcontext.SetKept(ctx)
+	}
+	return nil
+}
+
+func (f *fakeResource) Name() string {
+	return "fake"
+}
+
+func Test_Gate_EnsureDeleted(t *testing.T) {
+	testCases := []struct {
+		name            string
+		currentPhase    string
+		gatePhase       string
+		keptBeforehand  bool
+		cancel          bool
+		keep            bool
+		expectRan       bool
+		expectCanceled  bool
+		expectKept      bool
+		expectCompleted bool
+	}{
+		{
+			name:            "case 0: resource of a later phase waits",
+			currentPhase:    key.DeletionPhaseApps,
+			gatePhase:       key.DeletionPhaseNodePools,
+			expectRan:       false,
+			expectKept:      true,
+			expectCompleted: false,
+		},
+		{
+			name:            "case 1: resource of the current phase finishes",
+			currentPhase:    key.DeletionPhaseNodePools,
+			gatePhase:       key.DeletionPhaseNodePools,
+			expectRan:       true,
+			expectCompleted: true,
+		},
+		{
+			name:            "case 2: resource keeping finalizers does not finish",
+			currentPhase:    key.DeletionPhaseNodePools,
+			gatePhase:       key.DeletionPhaseNodePools,
+			keep:            true,
+			expectRan:       true,
+			expectKept:      true,
+			expectCompleted: false,
+		},
+		{
+			name:            "case 3: resource canceling the reconciliation does not finish",
+			currentPhase:    key.DeletionPhaseInfrastructure,
+			gatePhase:       key.DeletionPhaseApps,
+			cancel:          true,
+			expectRan:       true,
+			expectCanceled:  true,
+			expectCompleted: false,
+		},
+		{
+			name:            "case 4: finalizers kept by previous resources do not matter",
+			currentPhase:    key.DeletionPhaseNodePools,
+			gatePhase:       key.DeletionPhaseNodePools,
+			keptBeforehand:  true,
+			expectRan:       true,
+			expectKept:      true,
+			expectCompleted: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			ctx := reconciliationcanceledcontext.NewContext(context.Background(), make(chan struct{}))
+			ctx = finalizerskeptcontext.NewContext(ctx, make(chan struct{}))
+			if tc.keptBeforehand {
+				finalizerskeptcontext.SetKept(ctx)
+			}
+
+			cl := unittest.DefaultCluster()
+
+			state := NewState()
+			state.SetPhase(&cl, tc.currentPhase)
+
+			f := &fakeResource{cancel: tc.cancel, keep: tc.keep}
+
+			var resources []resource.Interface
+			{
+				c := WrapConfig{
+					Logger: microloggertest.New(),
+					Phases: map[resource.Interface]string{f: tc.gatePhase},
+					State:  state,
+				}
+
+				var err error
+				resources, err = Wrap([]resource.Interface{f}, c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := resources[0].EnsureDeleted(ctx, &cl)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if f.ran != tc.expectRan {
+				t.Fatalf("expected ran %t, got %t", tc.expectRan, f.ran)
+			}
+			if reconciliationcanceledcontext.IsCanceled(ctx) != tc.expectCanceled {
+				t.Fatalf("expected canceled %t, got %t", tc.expectCanceled, reconciliationcanceledcontext.IsCanceled(ctx))
+			}
+			if finalizerskeptcontext.IsKept(ctx) != tc.expectKept {
+				t.Fatalf("expected kept %t, got %t", tc.expectKept, finalizerskeptcontext.IsKept(ctx))
+			}
+			if state.Completed(&cl, tc.gatePhase) != tc.expectCompleted {
+				t.Fatalf("expected completed %t, got %t", tc.expectCompleted, state.Completed(&cl, tc.gatePhase))
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package deletionphase
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	phaseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "cluster_operator",
+			Subsystem: "deletion_phase",
+			Name:      "duration_seconds",
+			Help:      "Duration of completed tenant cluster deletion phases.",
+			Buckets:   prometheus.ExponentialBuckets(30, 2, 10),
+		},
+		[]string{"phase"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(phaseDuration)
+}
@@ -0,0 +1,40 @@
+package deletionphase
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+// completedPhase is a deletion phase completed during the current
+// reconciliation loop.
+type completedPhase struct {
+	Duration time.Duration
+	Name     string
+}
+
+// advance returns the deletion phase following all completed phases starting
+// with the given one, together with the phases completed on the way. The last
+// phase never completes, because the Cluster CR is gone once it did.
+func advance(p key.DeletionPhase, now time.Time, completed func(phase string) bool) (key.DeletionPhase, []completedPhase) {
+	var done []completedPhase
+
+	for {
+		i := key.DeletionPhaseIndex(p.Name)
+		if i < 0 || i == len(key.DeletionPhases)-1 || !completed(p.Name) {
+			return p, done
+		}
+
+		done = append(done, completedPhase{
+			Duration: now.Sub(p.Since.Time),
+			Name:     p.Name,
+		})
+
+		p = key.DeletionPhase{
+			Name:  key.DeletionPhases[i+1],
+			Since: metav1.NewTime(now),
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package deletionphase
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+func Test_advance(t *testing.T) {
+	start := time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC)
+	now := start.Add(10 * time.Minute)
+
+	testCases := []struct {
+		name              string
+		phase             key.DeletionPhase
+		completed         []string
+		expectedPhase     key.DeletionPhase
+		expectedCompleted []completedPhase
+	}{
+		{
+			name:              "case 0: incomplete phase is kept",
+			phase:             key.DeletionPhase{Name: key.DeletionPhaseApps, Since: metav1.NewTime(start), Overrun: true},
+			completed:         nil,
+			expectedPhase:     key.DeletionPhase{Name: key.DeletionPhaseApps, Since: metav1.NewTime(start), Overrun: true},
+			expectedCompleted: nil,
+		},
+		{
+			name:          "case 1: completed phase advances",
+			phase:         key.DeletionPhase{Name: key.DeletionPhaseApps, Since: metav1.NewTime(start), Overrun: true},
+			completed:     []string{key.DeletionPhaseApps},
+			expectedPhase: key.DeletionPhase{Name: key.DeletionPhaseNodePools, Since: metav1.NewTime(now)},
+			expectedCompleted: []completedPhase{
+				{Duration: 10 * time.Minute, Name: key.DeletionPhaseApps},
+			},
+		},
+		{
+			name:          "case 2: several completed phases advance at once",
+			phase:         key.DeletionPhase{Name: key.DeletionPhaseNodePools, Since: metav1.NewTime(start)},
+			completed:     []string{key.DeletionPhaseNodePools, key.DeletionPhaseControlPlane},
+			expectedPhase: key.DeletionPhase{Name: key.DeletionPhaseInfrastructure, Since: metav1.NewTime(now)},
+			expectedCompleted: []completedPhase{
+				{Duration: 10 * time.Minute, Name: key.DeletionPhaseNodePools},
+				{Duration: 0, Name: key.DeletionPhaseControlPlane},
+			},
+		},
+		{
+			name:              "case 3: last phase never completes",
+			phase:             key.DeletionPhase{Name: key.DeletionPhaseManagement, Since: metav1.NewTime(start)},
+			completed:         []string{key.DeletionPhaseManagement},
+			expectedPhase:     key.DeletionPhase{Name: key.DeletionPhaseManagement, Since: metav1.NewTime(start)},
+			expectedCompleted: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			completed := func(phase string) bool {
+				for _, p := range tc.completed {
+					if p == phase {
+						return true
+					}
+				}
+				return false
+			}
+
+			phase, done := advance(tc.phase, now, completed)
+
+			if !reflect.DeepEqual(phase, tc.expectedPhase) {
+				t.Fatalf("expected phase %#v to be equal to %#v", tc.expectedPhase, phase)
+			}
+			if !reflect.DeepEqual(done, tc.expectedCompleted) {
+				t.Fatalf("expected completed %#v to be equal to %#v", tc.expectedCompleted, done)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package deletionphase
+
+import (
+	"time"
+
+	"github.com/giantswarm/k8sclient/v5/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/recorder"
+)
+
+const (
+	Name = "deletionphase"
+
+	// DefaultTimeout is the timeout of deletion phases not configured
+	// explicitly.
+	DefaultTimeout = 30 * time.Minute
+)
+
+type Config struct {
+	Event     recorder.Interface
+	K8sClient k8sclient.Interface
+	Logger    micrologger.Logger
+	State     *State
+
+	// Timeouts maps deletion phases to the duration after which the phase is
+	// considered overrun. Phases not configured use DefaultTimeout.
+	Timeouts map[string]time.Duration
+}
+
+// Resource runs the deletion of tenant clusters in the ordered phases defined
+// by key.DeletionPhases. It must run right after the deletion protection,
+// because it decides which of the gated resources wrapped using Wrap run
+// during the reconciliation loop. The current phase is recorded in the
+// deletion phase annotation of the Cluster CR. The phase advances once all
+// gated resources of the phase finished their work. Phases exceeding their
+// timeout are reported using Warning events.
+type Resource struct {
+	event     recorder.Interface
+	k8sClient k8sclient.Interface
+	logger    micrologger.Logger
+	state     *State
+
+	timeouts map[string]time.Duration
+}
+
+func New(config Config) (*Resource, error) {
+	if config.Event == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Event must not be empty", config)
+	}
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.State == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.State must not be empty", config)
+	}
+
+	timeouts := map[string]time.Duration{}
+	for _, p := range key.DeletionPhases {
+		timeouts[p] = DefaultTimeout
+	}
+	for p, t := range config.Timeouts {
+		if key.DeletionPhaseIndex(p) < 0 {
+			return nil, microerror.Maskf(invalidConfigError, "%T.Timeouts contains unknown phase %#q", config, p)
+		}
+		if t <= 0 {
+			return nil, microerror.Maskf(invalidConfigError, "%T.Timeouts[%s] must be positive", config, p)
+		}
+
+		timeouts[p] = t
+	}
+
+	r := &Resource{
+		event:     config.Event,
+		k8sClient: config.K8sClient,
+		logger:    config.Logger,
+		state:     config.State,
+
+		timeouts: timeouts,
+	}
+
+	return r, nil
+}
+
+func (r *Resource) Name() string {
+	return Name
+}
@@ -0,0 +1,121 @@
+package deletionphase
+
+import (
+	"sync"
+	"time"
+
+	gocache "github.com/patrickmn/go-cache"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+const (
+	// stateExpiration is the duration the state of a tenant cluster is kept
+	// after it was last accessed. It only has to outlive the time between two
+	// reconciliation loops.
+	stateExpiration = time.Hour
+)
+
+// State is shared by the Resource and the gates wrapping the resources of the
+// Cluster controller. It holds the current deletion phase of every tenant
+// cluster being deleted and whether the gated resources finished their work
+// the last time they ran.
+type State struct {
+	cache *gocache.Cache
+
+	mutex sync.Mutex
+	gates map[string][]int
+	next  int
+}
+
+type clusterState struct {
+	finished map[int]bool
+	phase    string
+}
+
+func NewState() *State {
+	s := &State{
+		cache: gocache.New(stateExpiration, stateExpiration/2),
+
+		gates: map[string][]int{},
+	}
+
+	return s
+}
+
+// Completed returns true when all gated resources of the given phase finished
+// their work the last time they ran for the given tenant cluster.
+func (s *State) Completed(obj metav1.Object, phase string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	cs := s.clusterState(obj)
+	for _, id := range s.gates[phase] {
+		if !cs.finished[id] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Phase returns the current deletion phase of the given tenant cluster as set
+// by the Resource during the current or a recent reconciliation loop.
+func (s *State) Phase(obj metav1.Object) (string, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	cs := s.clusterState(obj)
+
+	return cs.phase, cs.phase != ""
+}
+
+// SetPhase sets the current deletion phase of the given tenant cluster.
+// Changing the phase forgets which gated resources finished their work.
+func (s *State) SetPhase(obj metav1.Object, phase string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	cs := s.clusterState(obj)
+	if cs.phase != phase {
+		cs.finished = map[int]bool{}
+		cs.phase = phase
+	}
+}
+
+func (s *State) finish(obj metav1.Object, id int, finished bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.clusterState(obj).finished[id] = finished
+}
+
+func (s *State) register(phase string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	id := s.next
+	s.next++
+	s.gates[phase] = append(s.gates[phase], id)
+
+	return id
+}
+
+// clusterState returns the state of the given tenant cluster, creating it if
+// necessary. The caller must hold the mutex.
+func (s *State) clusterState(obj metav1.Object) *clusterState {
+	k := key.ClusterID(obj)
+
+	var cs *clusterState
+	if v, ok := s.cache.Get(k); ok {
+		cs = v.(*clusterState)
+	} else {
+		cs = &clusterState{finished: map[int]bool{}}
+	}
+
+	// Setting the state on every access extends its expiration.
+	s.cache.SetDefault(k, cs)
+
+	return cs
+}
@@ -0,0 +1,115 @@
+package deletionprotection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/reconciliationcanceledcontext"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	apiv1alpha3 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	// The deletion protection may have been removed after the reconciliation
+	// loop started, so we must not decide based on the cached object.
+	var cl apiv1alpha2.Cluster
+	{
+		r.logger.Debugf(ctx, "finding latest cluster")
+
+		err = r.k8sClient.CtrlClient().Get(ctx, types.NamespacedName{Name: cr.GetName(), Namespace: cr.GetNamespace()}, &cl)
+		if apierrors.IsNotFound(err) {
+			r.logger.Debugf(ctx, "did not find latest cluster")
+			r.logger.Debugf(ctx, "canceling resource")
+			return nil
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "found latest cluster")
+	}
+
+	protected := key.IsDeletionProtected(&cl)
+
+	var condition apiv1alpha3.Condition
+	if protected {
+		condition = apiv1alpha3.Condition{
+			Type:    key.DeletionBlockedCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  "DeletionProtected",
+			Message: fmt.Sprintf("deletion is blocked by annotation %#q, remove it to delete the cluster", annotation.DeletionProtection),
+		}
+	} else {
+		condition = apiv1alpha3.Condition{
+			Type:   key.DeletionBlockedCondition,
+			Status: corev1.ConditionFalse,
+		}
+	}
+
+	// Clusters which were never protected do not need the condition.
+	current := key.Condition(key.Conditions(&cl), key.DeletionBlockedCondition)
+	if protected || current != nil {
+		err = r.ensureCondition(ctx, &cl, condition)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	if protected {
+		r.logger.Debugf(ctx, "cluster is protected from deletion")
+		r.logger.Debugf(ctx, "keeping finalizers")
+		r.logger.Debugf(ctx, "canceling reconciliation")
+		reconciliationcanceledcontext.SetCanceled(ctx)
+		return nil
+	}
+
+	return nil
+}
+
+func (r *Resource) ensureCondition(ctx context.Context, cl *apiv1alpha2.Cluster, condition apiv1alpha3.Condition) error {
+	conditions, changed := key.WithCondition(key.Conditions(cl), condition)
+	if !changed {
+		r.logger.Debugf(ctx, "condition %#q is up to date", condition.Type)
+		return nil
+	}
+
+	{
+		r.logger.Debugf(ctx, "updating condition %#q", condition.Type)
+
+		patch := client.MergeFrom(cl.DeepCopy())
+
+		a := cl.GetAnnotations()
+		if a == nil {
+			a = map[string]string{}
+		}
+		a[annotation.Conditions] = key.ConditionsAnnotation(conditions)
+		cl.SetAnnotations(a)
+
+		err := r.k8sClient.CtrlClient().Patch(ctx, cl, patch)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "updated condition %#q", condition.Type)
+	}
+
+	if condition.Status == corev1.ConditionTrue {
+		r.event.Warn(ctx, cl, condition.Reason, condition.Message)
+	} else {
+		r.event.Emit(ctx, cl, "DeletionUnprotected", "deletion protection was removed, deleting cluster")
+	}
+
+	return nil
+}
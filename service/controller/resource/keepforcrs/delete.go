@@ -6,6 +6,7 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/finalizerskeptcontext"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -20,12 +21,15 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 		return microerror.Mask(err)
 	}
 
+	var kind string
 	var list *unstructured.UnstructuredList
 	{
 		gvk, err := apiutil.GVKForObject(r.newObjFunc(), r.k8sClient.Scheme())
 		if err != nil {
 			return microerror.Mask(err)
 		}
+
+		kind = gvk.Kind
 		gvk.Kind += "List"
 
 		l := &unstructured.UnstructuredList{}
@@ -50,6 +54,18 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 		r.logger.Debugf(ctx, "found %d object(s) of type %T for tenant cluster %#q", len(list.Items), r.newObjFunc(), key.ClusterID(cr))
 	}
 
+	if r.deletionBlocker != nil {
+		var objs []metav1.Object
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+
+		err = r.deletionBlocker.Report(ctx, obj, kind, objs)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
 	if len(list.Items) != 0 {
 		r.logger.Debugf(ctx, "keeping finalizers")
 		finalizerskeptcontext.SetKept(ctx)
@@ -5,6 +5,8 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/giantswarm/cluster-operator/v3/service/internal/deletionblocker"
 )
 
 const (
@@ -12,8 +14,11 @@ const (
 )
 
 type Config struct {
-	K8sClient k8sclient.Interface
-	Logger    micrologger.Logger
+	// DeletionBlocker is optional. When given, the objects keeping finalizers
+	// are reported as deletion blockers.
+	DeletionBlocker deletionblocker.Interface
+	K8sClient       k8sclient.Interface
+	Logger          micrologger.Logger
 	// NewObjFunc is to return an instance of a pointer for the CR type that
 	// should be considered for keeping finalizers.
 	//
@@ -34,9 +39,10 @@ type Config struct {
 //     Cluster    |    MachineDeployment
 //
 type Resource struct {
-	k8sClient  k8sclient.Interface
-	logger     micrologger.Logger
-	newObjFunc func() runtime.Object
+	deletionBlocker deletionblocker.Interface
+	k8sClient       k8sclient.Interface
+	logger          micrologger.Logger
+	newObjFunc      func() runtime.Object
 }
 
 func New(config Config) (*Resource, error) {
@@ -51,9 +57,10 @@ func New(config Config) (*Resource, error) {
 	}
 
 	r := &Resource{
-		k8sClient:  config.K8sClient,
-		logger:     config.Logger,
-		newObjFunc: config.NewObjFunc,
+		deletionBlocker: config.DeletionBlocker,
+		k8sClient:       config.K8sClient,
+		logger:          config.Logger,
+		newObjFunc:      config.NewObjFunc,
 	}
 
 	return r, nil
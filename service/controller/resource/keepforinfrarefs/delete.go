@@ -6,6 +6,7 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/finalizerskeptcontext"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
@@ -50,6 +51,12 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 			// does not exist anymore, which means the deletion of the parent can
 			// continue now.
 			r.logger.Debugf(ctx, "did not find infrastructure reference")
+
+			err = r.reportDeletionBlocker(ctx, obj, or.Kind, nil)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
 			r.logger.Debugf(ctx, "continue deletion of parent runtime object")
 			return nil
 		} else if err != nil {
@@ -57,9 +64,33 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 		}
 
 		r.logger.Debugf(ctx, "found infrastructure reference")
+
+		err = r.reportDeletionBlocker(ctx, obj, or.Kind, ir)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
 		r.logger.Debugf(ctx, "keeping finalizers")
 		finalizerskeptcontext.SetKept(ctx)
 	}
 
 	return nil
 }
+
+func (r *Resource) reportDeletionBlocker(ctx context.Context, obj interface{}, kind string, ir metav1.Object) error {
+	if r.deletionBlocker == nil {
+		return nil
+	}
+
+	var objs []metav1.Object
+	if ir != nil {
+		objs = append(objs, ir)
+	}
+
+	err := r.deletionBlocker.Report(ctx, obj, kind, objs)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/giantswarm/cluster-operator/v3/service/internal/deletionblocker"
 )
 
 const (
@@ -12,8 +14,11 @@ const (
 )
 
 type Config struct {
-	K8sClient k8sclient.Interface
-	Logger    micrologger.Logger
+	// DeletionBlocker is optional. When given, the infrastructure reference
+	// keeping finalizers is reported as deletion blocker.
+	DeletionBlocker deletionblocker.Interface
+	K8sClient       k8sclient.Interface
+	Logger          micrologger.Logger
 
 	ToObjRef func(v interface{}) (corev1.ObjectReference, error)
 }
@@ -24,8 +29,9 @@ type Config struct {
 // an AWSCluster CR in its infrastructure reference. When a cluster is deleted
 // the Cluster CR must not be deleted as long as the AWSCluster CR exists.
 type Resource struct {
-	k8sClient k8sclient.Interface
-	logger    micrologger.Logger
+	deletionBlocker deletionblocker.Interface
+	k8sClient       k8sclient.Interface
+	logger          micrologger.Logger
 
 	toObjRef func(v interface{}) (corev1.ObjectReference, error)
 }
@@ -43,8 +49,9 @@ func New(config Config) (*Resource, error) {
 	}
 
 	r := &Resource{
-		k8sClient: config.K8sClient,
-		logger:    config.Logger,
+		deletionBlocker: config.DeletionBlocker,
+		k8sClient:       config.K8sClient,
+		logger:          config.Logger,
 
 		toObjRef: config.ToObjRef,
 	}
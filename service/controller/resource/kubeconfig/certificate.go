@@ -0,0 +1,102 @@
+package kubeconfig
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/giantswarm/certs/v3/pkg/certs"
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/rest"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+// parseCertificate returns the client certificate embedded in the given rest
+// config.
+func parseCertificate(restConfig *rest.Config) (*x509.Certificate, error) {
+	b, _ := pem.Decode(restConfig.TLSClientConfig.CertData)
+	if b == nil {
+		return nil, microerror.Maskf(invalidCertificateError, "client certificate must be PEM encoded")
+	}
+
+	c, err := x509.ParseCertificate(b.Bytes)
+	if err != nil {
+		return nil, microerror.Maskf(invalidCertificateError, err.Error())
+	}
+
+	return c, nil
+}
+
+// needsRenewal returns whether the given certificate expires soon enough for
+// us to ask cert-operator to issue a new one, which is the case once less than
+// a third of its lifetime remains at the given time.
+func needsRenewal(c *x509.Certificate, now time.Time) bool {
+	return c.NotAfter.Sub(now) < c.NotAfter.Sub(c.NotBefore)/renewalDivisor
+}
+
+// renewCertificate deletes the secrets cert-operator stores the given
+// certificate in. cert-operator then issues a new certificate on its next
+// reconciliation, which we pick up once it becomes available. It must only be
+// called for certificates owned by kubeconfig profiles, which nothing else
+// consumes.
+func (r *Resource) renewCertificate(ctx context.Context, cr apiv1alpha2.Cluster, cert certs.Cert, c *x509.Certificate) error {
+	r.logger.Debugf(ctx, "certificate %#q of tenant cluster %#q expires at %s", cert, key.ClusterID(&cr), c.NotAfter.UTC().Format(time.RFC3339))
+
+	o := metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(certs.K8sLabels(key.ClusterID(&cr), cert)).String(),
+	}
+
+	list, err := r.k8sClient.CoreV1().Secrets(certs.SecretNamespace).List(ctx, o)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for _, s := range list.Items {
+		r.logger.Debugf(ctx, "deleting secret %#q in namespace %#q", s.Name, s.Namespace)
+
+		err = r.k8sClient.CoreV1().Secrets(s.Namespace).Delete(ctx, s.Name, metav1.DeleteOptions{})
+		if apierrors.IsNotFound(err) {
+			// fall through
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "deleted secret %#q in namespace %#q", s.Name, s.Namespace)
+	}
+
+	r.event.Emit(ctx, &cr, "KubeConfigRenewing", fmt.Sprintf("requested new certificate %#q expiring at %s", cert, c.NotAfter.UTC().Format(time.RFC3339)))
+
+	return nil
+}
+
+// emitRotationEvents emits an event for every applied kubeconfig secret
+// embedding another certificate than before. The given serials map the names
+// of the secrets to the serials of their certificates before the secrets got
+// applied. Consumers like app-operator can use these events to reload their
+// tenant cluster clients. Secrets created before the certificate got annotated
+// are updated silently.
+func (c *CRUD) emitRotationEvents(ctx context.Context, cr apiv1alpha2.Cluster, serials map[string]string, secrets []*corev1.Secret) {
+	for _, s := range secrets {
+		if s.Type == capiSecretType {
+			continue
+		}
+
+		serial := serials[s.Name]
+		if serial == "" || serial == s.Annotations[annotation.KubeConfigCertSerial] {
+			continue
+		}
+
+		c.logger.Debugf(ctx, "kubeconfig secret %#q embeds certificate with serial %#q instead of %#q", s.Name, s.Annotations[annotation.KubeConfigCertSerial], serial)
+
+		c.event.Emit(ctx, &cr, "KubeConfigRotated", fmt.Sprintf("kubeconfig secret %#q rotated, certificate expires at %s", s.Name, s.Annotations[annotation.KubeConfigCertNotAfter]))
+	}
+}
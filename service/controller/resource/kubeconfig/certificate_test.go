@@ -0,0 +1,269 @@
+package kubeconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+)
+
+func Test_parseCertificate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		certData       []byte
+		expectedSerial string
+		errorMatcher   func(error) bool
+	}{
+		{
+			name:           "case 0: PEM encoded certificate",
+			certData:       newCertificatePEM(t, 42, time.Now(), time.Now().Add(time.Hour)),
+			expectedSerial: "42",
+		},
+		{
+			name:         "case 1: certificate not PEM encoded",
+			certData:     []byte("certificate"),
+			errorMatcher: IsInvalidCertificate,
+		},
+		{
+			name:         "case 2: PEM block not holding a certificate",
+			certData:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("certificate")}),
+			errorMatcher: IsInvalidCertificate,
+		},
+		{
+			name:         "case 3: no certificate",
+			certData:     nil,
+			errorMatcher: IsInvalidCertificate,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			restConfig := &rest.Config{
+				TLSClientConfig: rest.TLSClientConfig{
+					CertData: tc.certData,
+				},
+			}
+
+			c, err := parseCertificate(restConfig)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if tc.errorMatcher != nil {
+				return
+			}
+
+			if c.SerialNumber.String() != tc.expectedSerial {
+				t.Fatalf("expected %#q to be equal to %#q", tc.expectedSerial, c.SerialNumber.String())
+			}
+		})
+	}
+}
+
+func Test_needsRenewal(t *testing.T) {
+	now := time.Date(2020, 12, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name                 string
+		notBefore            time.Time
+		notAfter             time.Time
+		expectedNeedsRenewal bool
+	}{
+		{
+			name:                 "case 0: fresh certificate with short TTL",
+			notBefore:            now,
+			notAfter:             now.Add(12 * time.Hour),
+			expectedNeedsRenewal: false,
+		},
+		{
+			name:                 "case 1: certificate with short TTL in its last third",
+			notBefore:            now.Add(-9 * time.Hour),
+			notAfter:             now.Add(3 * time.Hour),
+			expectedNeedsRenewal: true,
+		},
+		{
+			name:                 "case 2: certificate with long TTL expiring in more than a day",
+			notBefore:            now.Add(-300 * 24 * time.Hour),
+			notAfter:             now.Add(65 * 24 * time.Hour),
+			expectedNeedsRenewal: true,
+		},
+		{
+			name:                 "case 3: certificate with long TTL in its first two thirds",
+			notBefore:            now.Add(-200 * 24 * time.Hour),
+			notAfter:             now.Add(165 * 24 * time.Hour),
+			expectedNeedsRenewal: false,
+		},
+		{
+			name:                 "case 4: expired certificate",
+			notBefore:            now.Add(-48 * time.Hour),
+			notAfter:             now.Add(-24 * time.Hour),
+			expectedNeedsRenewal: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			c := &x509.Certificate{
+				NotBefore: tc.notBefore,
+				NotAfter:  tc.notAfter,
+			}
+
+			needs := needsRenewal(c, now)
+			if needs != tc.expectedNeedsRenewal {
+				t.Fatalf("expected %t to be equal to %t", tc.expectedNeedsRenewal, needs)
+			}
+		})
+	}
+}
+
+func Test_CRUD_emitRotationEvents(t *testing.T) {
+	testCases := []struct {
+		name           string
+		serials        map[string]string
+		secrets        []*corev1.Secret
+		expectedEvents []string
+	}{
+		{
+			name:    "case 0: created secret",
+			serials: map[string]string{},
+			secrets: []*corev1.Secret{
+				newKubeConfigSecret("a2wax-kubeconfig", "2", ""),
+			},
+			expectedEvents: nil,
+		},
+		{
+			name: "case 1: secret created before certificates got annotated",
+			serials: map[string]string{
+				"a2wax-kubeconfig": "",
+			},
+			secrets: []*corev1.Secret{
+				newKubeConfigSecret("a2wax-kubeconfig", "2", ""),
+			},
+			expectedEvents: nil,
+		},
+		{
+			name: "case 2: secret embedding the same certificate",
+			serials: map[string]string{
+				"a2wax-kubeconfig": "2",
+			},
+			secrets: []*corev1.Secret{
+				newKubeConfigSecret("a2wax-kubeconfig", "2", ""),
+			},
+			expectedEvents: nil,
+		},
+		{
+			name: "case 3: rotated secrets",
+			serials: map[string]string{
+				"a2wax-kubeconfig":        "1",
+				"a2wax-kubeconfig-viewer": "3",
+			},
+			secrets: []*corev1.Secret{
+				newKubeConfigSecret("a2wax-kubeconfig", "2", ""),
+				newKubeConfigSecret("a2wax-kubeconfig-viewer", "4", ""),
+			},
+			expectedEvents: []string{
+				"KubeConfigRotated: kubeconfig secret `a2wax-kubeconfig` rotated, certificate expires at 2020-12-01T12:00:00Z",
+				"KubeConfigRotated: kubeconfig secret `a2wax-kubeconfig-viewer` rotated, certificate expires at 2020-12-01T12:00:00Z",
+			},
+		},
+		{
+			name: "case 4: Cluster API secret",
+			serials: map[string]string{
+				"a2wax-kubeconfig": "1",
+			},
+			secrets: []*corev1.Secret{
+				newKubeConfigSecret("a2wax-kubeconfig", "2", capiSecretType),
+			},
+			expectedEvents: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			e := &eventRecorder{}
+
+			c := &CRUD{
+				event:  e,
+				logger: microloggertest.New(),
+			}
+
+			c.emitRotationEvents(context.Background(), apiv1alpha2.Cluster{}, tc.serials, tc.secrets)
+
+			if !reflect.DeepEqual(e.events, tc.expectedEvents) {
+				t.Fatalf("expected %#v to be equal to %#v", tc.expectedEvents, e.events)
+			}
+		})
+	}
+}
+
+type eventRecorder struct {
+	events []string
+}
+
+func (e *eventRecorder) Emit(ctx context.Context, obj pkgruntime.Object, reason, message string) {
+	e.events = append(e.events, reason+": "+message)
+}
+
+func (e *eventRecorder) Warn(ctx context.Context, obj pkgruntime.Object, reason, message string) {
+	e.events = append(e.events, reason+": "+message)
+}
+
+func newCertificatePEM(t *testing.T, serial int64, notBefore, notAfter time.Time) []byte {
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "kubeconfig"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+
+	b, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &k.PublicKey, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: b})
+}
+
+func newKubeConfigSecret(name, serial string, secretType corev1.SecretType) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "a2wax",
+			Annotations: map[string]string{
+				annotation.KubeConfigCertNotAfter: "2020-12-01T12:00:00Z",
+				annotation.KubeConfigCertSerial:   serial,
+			},
+		},
+		Type: secretType,
+	}
+}
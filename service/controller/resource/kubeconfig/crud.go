@@ -0,0 +1,123 @@
+package kubeconfig
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/giantswarm/operatorkit/v4/pkg/resource/crud"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/deletionpreview"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/recorder"
+)
+
+// CRUDConfig represents the configuration used to create a new kubeconfig
+// CRUD.
+type CRUDConfig struct {
+	CRUD      crud.Interface
+	Event     recorder.Interface
+	K8sClient kubernetes.Interface
+	Logger    micrologger.Logger
+}
+
+// CRUD wraps the secret resource applying the kubeconfig secrets computed by
+// the kubeconfig state getter. It emits rotation events once updated secrets
+// embedding new certificates have been applied.
+type CRUD struct {
+	crud.Interface
+
+	event     recorder.Interface
+	k8sClient kubernetes.Interface
+	logger    micrologger.Logger
+}
+
+func NewCRUD(config CRUDConfig) (*CRUD, error) {
+	if config.CRUD == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CRUD must not be empty", config)
+	}
+	if config.Event == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Event must not be empty", config)
+	}
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	c := &CRUD{
+		Interface: config.CRUD,
+
+		event:     config.Event,
+		k8sClient: config.K8sClient,
+		logger:    config.Logger,
+	}
+
+	return c, nil
+}
+
+func (c *CRUD) ApplyUpdateChange(ctx context.Context, obj, updateChange interface{}) error {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	secrets, err := toSecrets(updateChange)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	// The update change carries the new annotations already, so we look up the
+	// serials of the certificates the secrets embed before applying it.
+	serials := map[string]string{}
+	for _, s := range secrets {
+		if s.Type == capiSecretType {
+			continue
+		}
+
+		current, err := c.k8sClient.CoreV1().Secrets(s.Namespace).Get(ctx, s.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		serials[s.Name] = current.Annotations[annotation.KubeConfigCertSerial]
+	}
+
+	err = c.Interface.ApplyUpdateChange(ctx, obj, updateChange)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	c.emitRotationEvents(ctx, cr, serials, secrets)
+
+	return nil
+}
+
+// PreviewDeleted returns no objects, because the kubeconfig secrets are not
+// deleted explicitly but vanish together with the cluster namespace. Computing
+// the desired state is not free of side effects, e.g. certificates due for
+// renewal are deleted, so the deletion of the secrets must not be previewed
+// using the state getters.
+func (c *CRUD) PreviewDeleted(ctx context.Context, obj interface{}) ([]deletionpreview.Object, error) {
+	return nil, nil
+}
+
+func toSecrets(v interface{}) ([]*corev1.Secret, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	secrets, ok := v.([]*corev1.Secret)
+	if !ok {
+		return nil, microerror.Maskf(wrongTypeError, "expected '%T', got '%T'", secrets, v)
+	}
+
+	return secrets, nil
+}
@@ -2,6 +2,7 @@ package kubeconfig
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/resourcecanceledcontext"
@@ -9,6 +10,8 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/pkg/project"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
 )
 
@@ -26,21 +29,58 @@ func (r *Resource) GetCurrentState(ctx context.Context, obj interface{}) ([]*cor
 		return nil, nil
 	}
 
-	var secret *corev1.Secret
+	var secrets []*corev1.Secret
 	{
-		r.logger.Debugf(ctx, "finding secret %#q for tenant cluster %#q", key.KubeConfigSecretName(&cr), key.ClusterID(&cr))
+		name := key.KubeConfigSecretName(&cr)
 
-		secret, err = r.k8sClient.CoreV1().Secrets(key.ClusterID(&cr)).Get(ctx, key.KubeConfigSecretName(&cr), metav1.GetOptions{})
-		if apierrors.IsNotFound(err) {
-			r.logger.Debugf(ctx, "did not find secret %#q for tenant cluster %#q", key.KubeConfigSecretName(&cr), key.ClusterID(&cr))
-			return nil, nil
+		r.logger.Debugf(ctx, "finding secret %#q for tenant cluster %#q", name, key.ClusterID(&cr))
 
+		secret, err := r.k8sClient.CoreV1().Secrets(key.ClusterID(&cr)).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			r.logger.Debugf(ctx, "did not find secret %#q for tenant cluster %#q", name, key.ClusterID(&cr))
 		} else if err != nil {
 			return nil, microerror.Mask(err)
+		} else {
+			r.logger.Debugf(ctx, "found secret %#q for tenant cluster %#q", name, key.ClusterID(&cr))
+			secrets = append(secrets, secret)
+		}
+	}
+
+	// The kubeconfig secrets of profiles are looked up by label, so that the
+	// secrets of profiles removed from the configuration are part of the
+	// current state and get deleted.
+	{
+		r.logger.Debugf(ctx, "finding kubeconfig profile secrets for tenant cluster %#q", key.ClusterID(&cr))
+
+		o := metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s,%s=%s,%s", label.Cluster, key.ClusterID(&cr), label.ManagedBy, project.Name(), label.KubeConfigProfile),
+		}
+
+		list, err := r.k8sClient.CoreV1().Secrets(key.ClusterID(&cr)).List(ctx, o)
+		if err != nil {
+			return nil, microerror.Mask(err)
 		}
 
-		r.logger.Debugf(ctx, "found secret %#q for tenant cluster %#q", key.KubeConfigSecretName(&cr), key.ClusterID(&cr))
+		for _, s := range list.Items {
+			secrets = append(secrets, s.DeepCopy())
+		}
+
+		r.logger.Debugf(ctx, "found %d kubeconfig profile secrets for tenant cluster %#q", len(list.Items), key.ClusterID(&cr))
+	}
+
+	if r.capiSecret && !isCAPISecretShared(cr) {
+		r.logger.Debugf(ctx, "finding secret %#q in namespace %#q", key.CAPIKubeConfigSecretName(cr), cr.GetNamespace())
+
+		secret, err := r.k8sClient.CoreV1().Secrets(cr.GetNamespace()).Get(ctx, key.CAPIKubeConfigSecretName(cr), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			r.logger.Debugf(ctx, "did not find secret %#q in namespace %#q", key.CAPIKubeConfigSecretName(cr), cr.GetNamespace())
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		} else {
+			r.logger.Debugf(ctx, "found secret %#q in namespace %#q", key.CAPIKubeConfigSecretName(cr), cr.GetNamespace())
+			secrets = append(secrets, secret)
+		}
 	}
 
-	return []*corev1.Secret{secret}, nil
+	return secrets, nil
 }
@@ -2,14 +2,20 @@ package kubeconfig
 
 import (
 	"context"
+	"crypto/x509"
+	"time"
 
+	"github.com/giantswarm/certs/v3/pkg/certs"
 	"github.com/giantswarm/kubeconfig/v2"
 	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/resourcecanceledcontext"
 	"github.com/giantswarm/tenantcluster/v3/pkg/tenantcluster"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
 
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
 	"github.com/giantswarm/cluster-operator/v3/pkg/label"
 	"github.com/giantswarm/cluster-operator/v3/pkg/project"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
@@ -28,8 +34,11 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 	{
 		restConfig, err = r.tenant.NewRestConfig(ctx, key.ClusterID(&cr), key.KubeConfigEndpoint(&cr, bd))
 		if tenantcluster.IsTimeout(err) {
+			// We cancel the resource in order to not delete any existing
+			// kubeconfig secret, which are all missing from the desired state.
 			r.logger.Debugf(ctx, "timeout fetching certificates")
 			r.logger.Debugf(ctx, "canceling resource")
+			resourcecanceledcontext.SetCanceled(ctx)
 			return nil, nil
 
 		} else if err != nil {
@@ -37,29 +46,133 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*cor
 		}
 	}
 
-	var secret *corev1.Secret
+	var secrets []*corev1.Secret
 	{
-		b, err := kubeconfig.NewKubeConfigForRESTConfig(ctx, restConfig, key.KubeConfigClusterName(&cr), "")
+		cert, err := parseCertificate(restConfig)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
 
-		secret = &corev1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      key.KubeConfigSecretName(&cr),
-				Namespace: key.ClusterID(&cr),
-				Labels: map[string]string{
-					label.Cluster:      key.ClusterID(&cr),
-					label.ManagedBy:    project.Name(),
-					label.Organization: key.OrganizationID(&cr),
-					label.ServiceType:  label.ServiceTypeManaged,
-				},
+		// The admin kubeconfig embeds the certificate of app-operator, which
+		// other consumers share. We leave its rotation to cert-operator.
+		if needsRenewal(cert, time.Now()) {
+			r.logger.Debugf(ctx, "not renewing shared certificate %#q expiring at %s", certs.AppOperatorAPICert, cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+
+		secret, err := newSecret(ctx, cr, restConfig, cert, key.KubeConfigSecretName(&cr), "")
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		if r.capiSecret && isCAPISecretShared(cr) {
+			secret.Data["value"] = secret.Data["kubeConfig"]
+			secret.Labels[label.CAPICluster] = cr.GetName()
+		}
+
+		secrets = append(secrets, secret)
+
+		if r.capiSecret && !isCAPISecretShared(cr) {
+			secrets = append(secrets, newCAPISecret(cr, secret))
+		}
+	}
+
+	for _, p := range r.profiles {
+		restConfig, err := p.tenant.NewRestConfig(ctx, key.ClusterID(&cr), key.KubeConfigEndpoint(&cr, bd))
+		if tenantcluster.IsTimeout(err) {
+			// The certificates of the kubeconfig profiles are issued alongside the
+			// admin certificate, so we only have to wait a little longer. We cancel
+			// the resource in order to not delete any existing kubeconfig secret.
+			r.logger.Debugf(ctx, "timeout fetching certificates for kubeconfig profile %#q", p.profile.Name)
+			r.logger.Debugf(ctx, "canceling resource")
+			resourcecanceledcontext.SetCanceled(ctx)
+			return nil, nil
+
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		cert, err := parseCertificate(restConfig)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		if needsRenewal(cert, time.Now()) {
+			err = r.renewCertificate(ctx, cr, p.cert, cert)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+
+			r.logger.Debugf(ctx, "canceling resource")
+			resourcecanceledcontext.SetCanceled(ctx)
+			return nil, nil
+		}
+
+		secret, err := newSecret(ctx, cr, restConfig, cert, key.KubeConfigProfileSecretName(&cr, p.profile), p.profile.Name)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nil
+}
+
+// newCAPISecret returns the Cluster API conformant version of the given admin
+// kubeconfig secret. It is owned by the Cluster CR so that it gets garbage
+// collected once the Cluster CR is gone.
+func newCAPISecret(cr apiv1alpha2.Cluster, secret *corev1.Secret) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        key.CAPIKubeConfigSecretName(cr),
+			Namespace:   cr.GetNamespace(),
+			Annotations: secret.Annotations,
+			Labels: map[string]string{
+				label.CAPICluster:  cr.GetName(),
+				label.Cluster:      key.ClusterID(&cr),
+				label.ManagedBy:    project.Name(),
+				label.Organization: key.OrganizationID(&cr),
 			},
-			Data: map[string][]byte{
-				"kubeConfig": b,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(&cr, apiv1alpha2.GroupVersion.WithKind("Cluster")),
 			},
-		}
+		},
+		Data: map[string][]byte{
+			"value": secret.Data["kubeConfig"],
+		},
+		Type: capiSecretType,
+	}
+}
+
+func newSecret(ctx context.Context, cr apiv1alpha2.Cluster, restConfig *rest.Config, cert *x509.Certificate, name string, profile string) (*corev1.Secret, error) {
+	b, err := kubeconfig.NewKubeConfigForRESTConfig(ctx, restConfig, key.KubeConfigClusterName(&cr), "")
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: key.ClusterID(&cr),
+			Annotations: map[string]string{
+				annotation.KubeConfigCertNotAfter: cert.NotAfter.UTC().Format(time.RFC3339),
+				annotation.KubeConfigCertSerial:   cert.SerialNumber.String(),
+			},
+			Labels: map[string]string{
+				label.Cluster:      key.ClusterID(&cr),
+				label.ManagedBy:    project.Name(),
+				label.Organization: key.OrganizationID(&cr),
+				label.ServiceType:  label.ServiceTypeManaged,
+			},
+		},
+		Data: map[string][]byte{
+			"kubeConfig": b,
+		},
+	}
+
+	if profile != "" {
+		secret.Labels[label.KubeConfigProfile] = profile
 	}
 
-	return []*corev1.Secret{secret}, nil
+	return secret, nil
 }
@@ -0,0 +1,338 @@
+package kubeconfig
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/certs/v3/pkg/certs"
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/resourcecanceledcontext"
+	"github.com/giantswarm/operatorkit/v4/pkg/resource/crud"
+	"github.com/giantswarm/operatorkit/v4/pkg/resource/k8s/secretresource"
+	"github.com/giantswarm/tenantcluster/v3/pkg/tenantcluster"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/pkg/project"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+func Test_newCAPISecret(t *testing.T) {
+	testCases := []struct {
+		name           string
+		cluster        apiv1alpha2.Cluster
+		expectedSecret *corev1.Secret
+		expectedShared bool
+	}{
+		{
+			name:    "case 0: Cluster CR in the default namespace",
+			cluster: newCluster("default", "my-cluster", "a2wax"),
+			expectedSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-cluster-kubeconfig",
+					Namespace: "default",
+					Annotations: map[string]string{
+						annotation.KubeConfigCertSerial: "1",
+					},
+					Labels: map[string]string{
+						label.CAPICluster:  "my-cluster",
+						label.Cluster:      "a2wax",
+						label.ManagedBy:    "cluster-operator",
+						label.Organization: "giantswarm",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						newControllerRef("my-cluster"),
+					},
+				},
+				Data: map[string][]byte{
+					"value": []byte("kubeconfig"),
+				},
+				Type: "cluster.x-k8s.io/secret",
+			},
+			expectedShared: false,
+		},
+		{
+			name:    "case 1: Cluster CR named after its cluster ID in another namespace",
+			cluster: newCluster("org-giantswarm", "a2wax", "a2wax"),
+			expectedSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "a2wax-kubeconfig",
+					Namespace: "org-giantswarm",
+					Annotations: map[string]string{
+						annotation.KubeConfigCertSerial: "1",
+					},
+					Labels: map[string]string{
+						label.CAPICluster:  "a2wax",
+						label.Cluster:      "a2wax",
+						label.ManagedBy:    "cluster-operator",
+						label.Organization: "giantswarm",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						newControllerRef("a2wax"),
+					},
+				},
+				Data: map[string][]byte{
+					"value": []byte("kubeconfig"),
+				},
+				Type: "cluster.x-k8s.io/secret",
+			},
+			expectedShared: false,
+		},
+		{
+			name:    "case 2: Cluster CR named after its cluster ID in the cluster namespace",
+			cluster: newCluster("a2wax", "a2wax", "a2wax"),
+			expectedSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "a2wax-kubeconfig",
+					Namespace: "a2wax",
+					Annotations: map[string]string{
+						annotation.KubeConfigCertSerial: "1",
+					},
+					Labels: map[string]string{
+						label.CAPICluster:  "a2wax",
+						label.Cluster:      "a2wax",
+						label.ManagedBy:    "cluster-operator",
+						label.Organization: "giantswarm",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						newControllerRef("a2wax"),
+					},
+				},
+				Data: map[string][]byte{
+					"value": []byte("kubeconfig"),
+				},
+				Type: "cluster.x-k8s.io/secret",
+			},
+			expectedShared: true,
+		},
+		{
+			name:    "case 3: Cluster CR named differently in the cluster namespace",
+			cluster: newCluster("a2wax", "my-cluster", "a2wax"),
+			expectedSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-cluster-kubeconfig",
+					Namespace: "a2wax",
+					Annotations: map[string]string{
+						annotation.KubeConfigCertSerial: "1",
+					},
+					Labels: map[string]string{
+						label.CAPICluster:  "my-cluster",
+						label.Cluster:      "a2wax",
+						label.ManagedBy:    "cluster-operator",
+						label.Organization: "giantswarm",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						newControllerRef("my-cluster"),
+					},
+				},
+				Data: map[string][]byte{
+					"value": []byte("kubeconfig"),
+				},
+				Type: "cluster.x-k8s.io/secret",
+			},
+			expectedShared: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			admin := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "a2wax-kubeconfig",
+					Namespace: "a2wax",
+					Annotations: map[string]string{
+						annotation.KubeConfigCertSerial: "1",
+					},
+				},
+				Data: map[string][]byte{
+					"kubeConfig": []byte("kubeconfig"),
+				},
+			}
+
+			secret := newCAPISecret(tc.cluster, admin)
+			if !reflect.DeepEqual(secret, tc.expectedSecret) {
+				t.Fatalf("expected %#v to be equal to %#v", tc.expectedSecret, secret)
+			}
+
+			shared := isCAPISecretShared(tc.cluster)
+			if shared != tc.expectedShared {
+				t.Fatalf("expected %t to be equal to %t", tc.expectedShared, shared)
+			}
+		})
+	}
+}
+
+type baseDomain struct{}
+
+func (b baseDomain) BaseDomain(ctx context.Context, obj interface{}) (string, error) {
+	return "example.com", nil
+}
+
+// Test_Resource_GetDesiredState_timeout ensures that none of the existing
+// kubeconfig secrets is deleted when the certificates of the admin kubeconfig
+// cannot be fetched in time.
+func Test_Resource_GetDesiredState_timeout(t *testing.T) {
+	var err error
+
+	cluster := newCluster("default", "my-cluster", "a2wax")
+	profile := key.KubeConfigProfile{Name: "viewer"}
+
+	existing := []*corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.KubeConfigSecretName(&cluster),
+				Namespace: "a2wax",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.KubeConfigProfileSecretName(&cluster, profile),
+				Namespace: "a2wax",
+				Labels: map[string]string{
+					label.Cluster:           "a2wax",
+					label.KubeConfigProfile: profile.Name,
+					label.ManagedBy:         project.Name(),
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.CAPIKubeConfigSecretName(cluster),
+				Namespace: "default",
+			},
+		},
+	}
+
+	k8sClient := fake.NewSimpleClientset()
+	for _, s := range existing {
+		_, err = k8sClient.CoreV1().Secrets(s.Namespace).Create(context.Background(), s, metav1.CreateOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// No certificates exist, so fetching them times out.
+	var certsSearcher *certs.Searcher
+	{
+		c := certs.Config{
+			K8sClient: k8sClient,
+			Logger:    microloggertest.New(),
+
+			WatchTimeout: 10 * time.Millisecond,
+		}
+
+		certsSearcher, err = certs.NewSearcher(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var tenant *tenantcluster.TenantCluster
+	{
+		c := tenantcluster.Config{
+			CertsSearcher: certsSearcher,
+			Logger:        microloggertest.New(),
+
+			CertID: certs.AppOperatorAPICert,
+		}
+
+		tenant, err = tenantcluster.New(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var r *Resource
+	{
+		c := Config{
+			BaseDomain:    baseDomain{},
+			CertsSearcher: certsSearcher,
+			Event:         &eventRecorder{},
+			K8sClient:     k8sClient,
+			Logger:        microloggertest.New(),
+			Tenant:        tenant,
+
+			CAPISecret: true,
+			Profiles:   []key.KubeConfigProfile{profile},
+		}
+
+		r, err = New(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var resource *crud.Resource
+	{
+		c := secretresource.Config{
+			K8sClient: k8sClient,
+			Logger:    microloggertest.New(),
+
+			Name:        Name,
+			StateGetter: r,
+		}
+
+		ops, err := secretresource.New(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resource, err = crud.NewResource(crud.ResourceConfig{CRUD: ops, Logger: microloggertest.New()})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := resourcecanceledcontext.NewContext(context.Background(), make(chan struct{}))
+
+	err = resource.EnsureCreated(ctx, &cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !resourcecanceledcontext.IsCanceled(ctx) {
+		t.Fatalf("expected resource to be canceled")
+	}
+
+	for _, s := range existing {
+		_, err = k8sClient.CoreV1().Secrets(s.Namespace).Get(context.Background(), s.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("expected secret %#q to exist, got %#v", s.Name, err)
+		}
+	}
+}
+
+func newCluster(namespace, name, id string) apiv1alpha2.Cluster {
+	return apiv1alpha2.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				label.Cluster:      id,
+				label.Organization: "giantswarm",
+			},
+			UID: "uid",
+		},
+	}
+}
+
+func newControllerRef(name string) metav1.OwnerReference {
+	controller := true
+	blockOwnerDeletion := true
+
+	return metav1.OwnerReference{
+		APIVersion:         "cluster.x-k8s.io/v1alpha2",
+		Kind:               "Cluster",
+		Name:               name,
+		UID:                "uid",
+		Controller:         &controller,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	}
+}
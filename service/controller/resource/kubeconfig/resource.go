@@ -5,9 +5,13 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/giantswarm/tenantcluster/v3/pkg/tenantcluster"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
 
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/basedomain"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/recorder"
 )
 
 const (
@@ -15,22 +19,54 @@ const (
 	Name = "kubeconfig"
 )
 
+const (
+	// capiSecretType is the secret type Cluster API uses for the secrets it
+	// manages.
+	capiSecretType corev1.SecretType = "cluster.x-k8s.io/secret"
+
+	// renewalDivisor defines the renewal window of kubeconfig certificates
+	// relative to their lifetime. Certificates are renewed once less than a
+	// third of their lifetime remains. cert-operator usually rotates
+	// certificates well ahead of this, so renewing here only covers missed
+	// rotations. Being relative, the window holds for any certificate TTL.
+	renewalDivisor = 3
+)
+
 // Config represents the configuration used to create a new kubeconfig resource.
 type Config struct {
 	BaseDomain    basedomain.Interface
 	CertsSearcher certs.Interface
+	Event         recorder.Interface
 	K8sClient     kubernetes.Interface
 	Logger        micrologger.Logger
 	Tenant        tenantcluster.Interface
+
+	// CAPISecret defines whether to additionally publish the admin kubeconfig
+	// in the format Cluster API tooling expects. The secret is named after the
+	// Cluster CR and lives in its namespace.
+	CAPISecret bool
+	Profiles   []key.KubeConfigProfile
 }
 
 // Resource implements the kubeconfig resource.
 type Resource struct {
 	baseDomain    basedomain.Interface
 	certsSearcher certs.Interface
+	event         recorder.Interface
 	k8sClient     kubernetes.Interface
 	logger        micrologger.Logger
 	tenant        tenantcluster.Interface
+
+	capiSecret bool
+	profiles   []kubeConfigProfile
+}
+
+// kubeConfigProfile bundles a kubeconfig profile with the tenant cluster
+// service looking up the profile's certificate.
+type kubeConfigProfile struct {
+	cert    certs.Cert
+	profile key.KubeConfigProfile
+	tenant  tenantcluster.Interface
 }
 
 // New creates a new configured secret state getter resource managing kube
@@ -45,6 +81,9 @@ func New(config Config) (*Resource, error) {
 	if config.CertsSearcher == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.CertsSearcher must not be empty", config)
 	}
+	if config.Event == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Event must not be empty", config)
+	}
 	if config.K8sClient == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
 	}
@@ -55,13 +94,42 @@ func New(config Config) (*Resource, error) {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Tenant must not be empty", config)
 	}
 
+	var profiles []kubeConfigProfile
+	for _, p := range config.Profiles {
+		c := tenantcluster.Config{
+			CertsSearcher: config.CertsSearcher,
+			Logger:        config.Logger,
+
+			CertID: key.KubeConfigProfileCert(p),
+		}
+
+		t, err := tenantcluster.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		profiles = append(profiles, kubeConfigProfile{cert: key.KubeConfigProfileCert(p), profile: p, tenant: t})
+	}
+
 	r := &Resource{
 		baseDomain:    config.BaseDomain,
 		certsSearcher: config.CertsSearcher,
+		event:         config.Event,
 		k8sClient:     config.K8sClient,
 		logger:        config.Logger,
 		tenant:        config.Tenant,
+
+		capiSecret: config.CAPISecret,
+		profiles:   profiles,
 	}
 
 	return r, nil
 }
+
+// isCAPISecretShared returns whether the Cluster API kubeconfig secret of the
+// given Cluster CR coincides with the admin kubeconfig secret. This is the case
+// when the Cluster CR lives in the namespace named after its own cluster ID. The
+// admin kubeconfig secret then carries the Cluster API format in addition.
+func isCAPISecretShared(cr apiv1alpha2.Cluster) bool {
+	return cr.GetNamespace() == key.ClusterID(&cr) && key.CAPIKubeConfigSecretName(cr) == key.KubeConfigSecretName(&cr)
+}
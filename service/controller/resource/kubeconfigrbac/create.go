@@ -0,0 +1,321 @@
+package kubeconfigrbac
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/giantswarm/errors/tenant"
+	"github.com/giantswarm/microerror"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/pkg/project"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/tenantclient"
+)
+
+func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var k8sClient kubernetes.Interface
+	{
+		tenantClient, err := r.tenantClient.K8sClient(ctx, &cr)
+		if tenantclient.IsNotAvailable(err) {
+			r.logger.Debugf(ctx, "tenant client not available yet")
+			r.logger.Debugf(ctx, "canceling resource")
+			return nil
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		k8sClient = tenantClient.K8sClient()
+	}
+
+	err = r.deleteStaleBindings(ctx, k8sClient)
+	if tenant.IsAPINotAvailable(err) {
+		r.logger.Debugf(ctx, "tenant API not available yet")
+		r.logger.Debugf(ctx, "canceling resource")
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if len(r.profiles) == 0 {
+		r.logger.Debugf(ctx, "no kubeconfig profiles configured")
+		r.logger.Debugf(ctx, "canceling resource")
+		return nil
+	}
+
+	for _, p := range r.profiles {
+		if p.ClusterRole == "" {
+			continue
+		}
+
+		if len(p.Namespaces) == 0 {
+			err = r.ensureClusterRoleBinding(ctx, k8sClient, newClusterRoleBinding(p))
+		} else {
+			for _, ns := range p.Namespaces {
+				err = r.ensureRoleBinding(ctx, k8sClient, newRoleBinding(p, ns))
+				if err != nil {
+					break
+				}
+			}
+		}
+
+		if tenant.IsAPINotAvailable(err) {
+			r.logger.Debugf(ctx, "tenant API not available yet")
+			r.logger.Debugf(ctx, "canceling resource")
+			return nil
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+// deleteStaleBindings deletes the ClusterRoleBindings and RoleBindings of
+// kubeconfig profiles which are not configured anymore, or which do not bind
+// their ClusterRole in the given scope anymore.
+func (r *Resource) deleteStaleBindings(ctx context.Context, k8sClient kubernetes.Interface) error {
+	clusterRoleBindings := map[string]bool{}
+	roleBindings := map[string]bool{}
+	for _, p := range r.profiles {
+		if p.ClusterRole == "" {
+			continue
+		}
+
+		if len(p.Namespaces) == 0 {
+			clusterRoleBindings[key.KubeConfigProfileRBACName(p)] = true
+		} else {
+			for _, ns := range p.Namespaces {
+				roleBindings[ns+"/"+key.KubeConfigProfileRBACName(p)] = true
+			}
+		}
+	}
+
+	o := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s,%s", label.ManagedBy, project.Name(), label.KubeConfigProfile),
+	}
+
+	{
+		r.logger.Debugf(ctx, "finding stale cluster role bindings in tenant cluster")
+
+		list, err := k8sClient.RbacV1().ClusterRoleBindings().List(ctx, o)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		for _, b := range list.Items {
+			if clusterRoleBindings[b.Name] {
+				continue
+			}
+
+			r.logger.Debugf(ctx, "deleting stale cluster role binding %#q in tenant cluster", b.Name)
+
+			err = k8sClient.RbacV1().ClusterRoleBindings().Delete(ctx, b.Name, metav1.DeleteOptions{})
+			if apierrors.IsNotFound(err) {
+				// fall through
+			} else if err != nil {
+				return microerror.Mask(err)
+			}
+
+			r.logger.Debugf(ctx, "deleted stale cluster role binding %#q in tenant cluster", b.Name)
+		}
+	}
+
+	{
+		r.logger.Debugf(ctx, "finding stale role bindings in tenant cluster")
+
+		list, err := k8sClient.RbacV1().RoleBindings(metav1.NamespaceAll).List(ctx, o)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		for _, b := range list.Items {
+			if roleBindings[b.Namespace+"/"+b.Name] {
+				continue
+			}
+
+			r.logger.Debugf(ctx, "deleting stale role binding %#q in namespace %#q of tenant cluster", b.Name, b.Namespace)
+
+			err = k8sClient.RbacV1().RoleBindings(b.Namespace).Delete(ctx, b.Name, metav1.DeleteOptions{})
+			if apierrors.IsNotFound(err) {
+				// fall through
+			} else if err != nil {
+				return microerror.Mask(err)
+			}
+
+			r.logger.Debugf(ctx, "deleted stale role binding %#q in namespace %#q of tenant cluster", b.Name, b.Namespace)
+		}
+	}
+
+	return nil
+}
+
+func (r *Resource) ensureClusterRoleBinding(ctx context.Context, k8sClient kubernetes.Interface, desired *rbacv1.ClusterRoleBinding) error {
+	r.logger.Debugf(ctx, "finding cluster role binding %#q in tenant cluster", desired.Name)
+
+	current, err := k8sClient.RbacV1().ClusterRoleBindings().Get(ctx, desired.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		r.logger.Debugf(ctx, "did not find cluster role binding %#q in tenant cluster", desired.Name)
+		r.logger.Debugf(ctx, "creating cluster role binding %#q in tenant cluster", desired.Name)
+
+		_, err = k8sClient.RbacV1().ClusterRoleBindings().Create(ctx, desired, metav1.CreateOptions{})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "created cluster role binding %#q in tenant cluster", desired.Name)
+		return nil
+
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.Debugf(ctx, "found cluster role binding %#q in tenant cluster", desired.Name)
+
+	// The role reference of bindings is immutable, which is why we have to
+	// recreate the binding when the profile refers to another ClusterRole.
+	if current.RoleRef != desired.RoleRef {
+		r.logger.Debugf(ctx, "recreating cluster role binding %#q in tenant cluster", desired.Name)
+
+		err = k8sClient.RbacV1().ClusterRoleBindings().Delete(ctx, desired.Name, metav1.DeleteOptions{})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		_, err = k8sClient.RbacV1().ClusterRoleBindings().Create(ctx, desired, metav1.CreateOptions{})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "recreated cluster role binding %#q in tenant cluster", desired.Name)
+
+	} else if !reflect.DeepEqual(current.Subjects, desired.Subjects) {
+		r.logger.Debugf(ctx, "updating cluster role binding %#q in tenant cluster", desired.Name)
+
+		current.Subjects = desired.Subjects
+		_, err = k8sClient.RbacV1().ClusterRoleBindings().Update(ctx, current, metav1.UpdateOptions{})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "updated cluster role binding %#q in tenant cluster", desired.Name)
+	}
+
+	return nil
+}
+
+func (r *Resource) ensureRoleBinding(ctx context.Context, k8sClient kubernetes.Interface, desired *rbacv1.RoleBinding) error {
+	r.logger.Debugf(ctx, "finding role binding %#q in namespace %#q of tenant cluster", desired.Name, desired.Namespace)
+
+	current, err := k8sClient.RbacV1().RoleBindings(desired.Namespace).Get(ctx, desired.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		r.logger.Debugf(ctx, "did not find role binding %#q in namespace %#q of tenant cluster", desired.Name, desired.Namespace)
+		r.logger.Debugf(ctx, "creating role binding %#q in namespace %#q of tenant cluster", desired.Name, desired.Namespace)
+
+		_, err = k8sClient.RbacV1().RoleBindings(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+		if apierrors.IsNotFound(err) {
+			// The namespace does not exist (yet) in the tenant cluster. We do not
+			// manage namespaces, so we retry on the next reconciliation loop.
+			r.logger.Debugf(ctx, "did not find namespace %#q in tenant cluster", desired.Namespace)
+			return nil
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "created role binding %#q in namespace %#q of tenant cluster", desired.Name, desired.Namespace)
+		return nil
+
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.Debugf(ctx, "found role binding %#q in namespace %#q of tenant cluster", desired.Name, desired.Namespace)
+
+	if current.RoleRef != desired.RoleRef {
+		r.logger.Debugf(ctx, "recreating role binding %#q in namespace %#q of tenant cluster", desired.Name, desired.Namespace)
+
+		err = k8sClient.RbacV1().RoleBindings(desired.Namespace).Delete(ctx, desired.Name, metav1.DeleteOptions{})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		_, err = k8sClient.RbacV1().RoleBindings(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "recreated role binding %#q in namespace %#q of tenant cluster", desired.Name, desired.Namespace)
+
+	} else if !reflect.DeepEqual(current.Subjects, desired.Subjects) {
+		r.logger.Debugf(ctx, "updating role binding %#q in namespace %#q of tenant cluster", desired.Name, desired.Namespace)
+
+		current.Subjects = desired.Subjects
+		_, err = k8sClient.RbacV1().RoleBindings(desired.Namespace).Update(ctx, current, metav1.UpdateOptions{})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "updated role binding %#q in namespace %#q of tenant cluster", desired.Name, desired.Namespace)
+	}
+
+	return nil
+}
+
+func newClusterRoleBinding(p key.KubeConfigProfile) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   key.KubeConfigProfileRBACName(p),
+			Labels: newLabels(p),
+		},
+		RoleRef:  newRoleRef(p),
+		Subjects: newSubjects(p),
+	}
+}
+
+func newLabels(p key.KubeConfigProfile) map[string]string {
+	return map[string]string{
+		label.KubeConfigProfile: p.Name,
+		label.ManagedBy:         project.Name(),
+	}
+}
+
+func newRoleBinding(p key.KubeConfigProfile, namespace string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.KubeConfigProfileRBACName(p),
+			Namespace: namespace,
+			Labels:    newLabels(p),
+		},
+		RoleRef:  newRoleRef(p),
+		Subjects: newSubjects(p),
+	}
+}
+
+func newRoleRef(p key.KubeConfigProfile) rbacv1.RoleRef {
+	return rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "ClusterRole",
+		Name:     p.ClusterRole,
+	}
+}
+
+func newSubjects(p key.KubeConfigProfile) []rbacv1.Subject {
+	var subjects []rbacv1.Subject
+	for _, g := range key.KubeConfigProfileGroups(p) {
+		subjects = append(subjects, rbacv1.Subject{
+			APIGroup: rbacv1.GroupName,
+			Kind:     rbacv1.GroupKind,
+			Name:     g,
+		})
+	}
+
+	return subjects
+}
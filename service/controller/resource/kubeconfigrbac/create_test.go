@@ -0,0 +1,180 @@
+package kubeconfigrbac
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	tcunittest "github.com/giantswarm/cluster-operator/v3/service/internal/tenantclient/unittest"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/unittest"
+)
+
+func Test_Resource_EnsureCreated(t *testing.T) {
+	testCases := []struct {
+		name                        string
+		profiles                    []key.KubeConfigProfile
+		existingProfiles            []key.KubeConfigProfile
+		expectedClusterRoleBindings []string
+		expectedRoleBindings        []string
+	}{
+		{
+			name: "case 0: bindings of configured profiles are created",
+			profiles: []key.KubeConfigProfile{
+				{Name: "viewer", ClusterRole: "view"},
+				{Name: "editor", ClusterRole: "edit", Namespaces: []string{"default"}},
+				{Name: "external"},
+			},
+			expectedClusterRoleBindings: []string{
+				"giantswarm-kubeconfig-viewer",
+				"unmanaged",
+			},
+			expectedRoleBindings: []string{
+				"default/giantswarm-kubeconfig-editor",
+				"default/unmanaged",
+			},
+		},
+		{
+			name: "case 1: bindings of removed profiles are deleted",
+			profiles: []key.KubeConfigProfile{
+				{Name: "viewer", ClusterRole: "view"},
+			},
+			existingProfiles: []key.KubeConfigProfile{
+				{Name: "viewer", ClusterRole: "view"},
+				{Name: "admin", ClusterRole: "cluster-admin"},
+				{Name: "editor", ClusterRole: "edit", Namespaces: []string{"default"}},
+			},
+			expectedClusterRoleBindings: []string{
+				"giantswarm-kubeconfig-viewer",
+				"unmanaged",
+			},
+			expectedRoleBindings: []string{
+				"default/unmanaged",
+			},
+		},
+		{
+			name: "case 2: bindings outside of the configured scope are deleted",
+			profiles: []key.KubeConfigProfile{
+				{Name: "viewer", ClusterRole: "view", Namespaces: []string{"default"}},
+				{Name: "editor"},
+			},
+			existingProfiles: []key.KubeConfigProfile{
+				{Name: "viewer", ClusterRole: "view"},
+				{Name: "editor", ClusterRole: "edit", Namespaces: []string{"default", "kube-system"}},
+			},
+			expectedClusterRoleBindings: []string{
+				"unmanaged",
+			},
+			expectedRoleBindings: []string{
+				"default/giantswarm-kubeconfig-viewer",
+				"default/unmanaged",
+			},
+		},
+		{
+			name:     "case 3: bindings are deleted when no profile is configured",
+			profiles: nil,
+			existingProfiles: []key.KubeConfigProfile{
+				{Name: "viewer", ClusterRole: "view"},
+			},
+			expectedClusterRoleBindings: []string{
+				"unmanaged",
+			},
+			expectedRoleBindings: []string{
+				"default/unmanaged",
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var err error
+			ctx := context.Background()
+
+			k8sClient := unittest.FakeK8sClient()
+
+			{
+				_, err = k8sClient.K8sClient().RbacV1().ClusterRoleBindings().Create(ctx, &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged"}}, metav1.CreateOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				_, err = k8sClient.K8sClient().RbacV1().RoleBindings("default").Create(ctx, &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged", Namespace: "default"}}, metav1.CreateOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for _, p := range tc.existingProfiles {
+				if len(p.Namespaces) == 0 {
+					_, err = k8sClient.K8sClient().RbacV1().ClusterRoleBindings().Create(ctx, newClusterRoleBinding(p), metav1.CreateOptions{})
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+				for _, ns := range p.Namespaces {
+					_, err = k8sClient.K8sClient().RbacV1().RoleBindings(ns).Create(ctx, newRoleBinding(p, ns), metav1.CreateOptions{})
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			var r *Resource
+			{
+				c := Config{
+					Logger:       microloggertest.New(),
+					TenantClient: tcunittest.FakeTenantClient(k8sClient),
+
+					Profiles: tc.profiles,
+				}
+
+				r, err = New(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err = r.EnsureCreated(ctx, &apiv1alpha2.Cluster{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var clusterRoleBindings []string
+			{
+				list, err := k8sClient.K8sClient().RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, b := range list.Items {
+					clusterRoleBindings = append(clusterRoleBindings, b.Name)
+				}
+				sort.Strings(clusterRoleBindings)
+			}
+
+			var roleBindings []string
+			{
+				list, err := k8sClient.K8sClient().RbacV1().RoleBindings(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, b := range list.Items {
+					roleBindings = append(roleBindings, b.Namespace+"/"+b.Name)
+				}
+				sort.Strings(roleBindings)
+			}
+
+			if !reflect.DeepEqual(clusterRoleBindings, tc.expectedClusterRoleBindings) {
+				t.Fatalf("expected %#v to be equal to %#v", tc.expectedClusterRoleBindings, clusterRoleBindings)
+			}
+			if !reflect.DeepEqual(roleBindings, tc.expectedRoleBindings) {
+				t.Fatalf("expected %#v to be equal to %#v", tc.expectedRoleBindings, roleBindings)
+			}
+		})
+	}
+}
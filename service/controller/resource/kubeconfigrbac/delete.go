@@ -0,0 +1,11 @@
+package kubeconfigrbac
+
+import (
+	"context"
+)
+
+// EnsureDeleted is a no-op because the RBAC resources vanish together with the
+// tenant cluster.
+func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	return nil
+}
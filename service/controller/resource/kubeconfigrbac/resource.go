@@ -0,0 +1,61 @@
+package kubeconfigrbac
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/tenantclient"
+)
+
+const (
+	Name = "kubeconfigrbac"
+)
+
+type Config struct {
+	Logger       micrologger.Logger
+	TenantClient tenantclient.Interface
+
+	Profiles []key.KubeConfigProfile
+}
+
+// Resource implements the operatorkit resource interface to manage the RBAC
+// resources within tenant clusters which grant the groups of the configured
+// kubeconfig profiles their permissions. Profiles without ClusterRole are
+// ignored. Profiles with namespaces get a RoleBinding in each of these
+// namespaces, all other profiles get a ClusterRoleBinding. Bindings of profiles
+// removed from the configuration are deleted.
+//
+//     profile              |    tenant cluster
+//     ---------------------------------------------------
+//     clusterRole          |    ClusterRoleBinding
+//     clusterRole + ns     |    RoleBinding per namespace
+//
+type Resource struct {
+	logger       micrologger.Logger
+	tenantClient tenantclient.Interface
+
+	profiles []key.KubeConfigProfile
+}
+
+func New(config Config) (*Resource, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.TenantClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.TenantClient must not be empty", config)
+	}
+
+	r := &Resource{
+		logger:       config.Logger,
+		tenantClient: config.TenantClient,
+
+		profiles: config.Profiles,
+	}
+
+	return r, nil
+}
+
+func (r *Resource) Name() string {
+	return Name
+}
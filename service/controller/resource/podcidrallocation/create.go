@@ -0,0 +1,117 @@
+package podcidrallocation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1alpha3 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/podcidr"
+)
+
+func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	{
+		r.logger.Debugf(ctx, "allocating pod CIDR")
+
+		allocated, err := r.podCIDR.Allocate(ctx, &cr)
+		if podcidr.IsNotFound(err) {
+			r.logger.Debugf(ctx, "did not find infrastructure CR")
+			r.logger.Debugf(ctx, "canceling resource")
+			return nil
+		} else if podcidr.IsPoolExhausted(err) {
+			r.event.Warn(ctx, &cr, "PodCIDRPoolExhausted", "no pod CIDR left to allocate")
+			return microerror.Mask(err)
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		if allocated == "" {
+			r.logger.Debugf(ctx, "did not allocate pod CIDR")
+		} else {
+			r.logger.Debugf(ctx, "allocated pod CIDR %#q", allocated)
+			r.event.Emit(ctx, &cr, "PodCIDRAllocated", fmt.Sprintf("allocated pod CIDR %#q", allocated))
+		}
+	}
+
+	var condition apiv1alpha3.Condition
+	{
+		overlaps, err := r.podCIDR.Overlaps(ctx, &cr)
+		if podcidr.IsInvalidConfig(err) {
+			r.event.Warn(ctx, &cr, "PodCIDRInvalid", err.Error())
+			return microerror.Mask(err)
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		if len(overlaps) == 0 {
+			condition = apiv1alpha3.Condition{
+				Type:   key.PodCIDROverlappingCondition,
+				Status: corev1.ConditionFalse,
+			}
+		} else {
+			condition = apiv1alpha3.Condition{
+				Type:    key.PodCIDROverlappingCondition,
+				Status:  corev1.ConditionTrue,
+				Reason:  "PodCIDROverlapping",
+				Message: fmt.Sprintf("pod CIDR overlaps %s", strings.Join(overlaps, ", ")),
+			}
+		}
+	}
+
+	// Other resources update the conditions of the Cluster CR during the same
+	// reconciliation loop, so we must not patch based on the cached object.
+	{
+		r.logger.Debugf(ctx, "finding latest cluster")
+
+		err = r.k8sClient.CtrlClient().Get(ctx, types.NamespacedName{Name: cr.GetName(), Namespace: cr.GetNamespace()}, &cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "found latest cluster")
+	}
+
+	conditions, changed := key.WithCondition(key.Conditions(&cr), condition)
+	if !changed {
+		r.logger.Debugf(ctx, "condition %#q is up to date", condition.Type)
+		return nil
+	}
+
+	{
+		r.logger.Debugf(ctx, "updating condition %#q", condition.Type)
+
+		patch := client.MergeFrom(cr.DeepCopy())
+
+		a := cr.GetAnnotations()
+		if a == nil {
+			a = map[string]string{}
+		}
+		a[annotation.Conditions] = key.ConditionsAnnotation(conditions)
+		cr.SetAnnotations(a)
+
+		err = r.k8sClient.CtrlClient().Patch(ctx, &cr, patch)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "updated condition %#q", condition.Type)
+	}
+
+	if condition.Status == corev1.ConditionTrue {
+		r.event.Warn(ctx, &cr, condition.Reason, condition.Message)
+	}
+
+	return nil
+}
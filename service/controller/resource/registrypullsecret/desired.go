@@ -0,0 +1,93 @@
+package registrypullsecret
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/resourcecanceledcontext"
+	yaml "gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/pkg/project"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) ([]*corev1.Secret, error) {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	// No secret is desired when no pull secret is configured. A secret
+	// distributed before gets deleted then.
+	if r.pullSecretName == "" {
+		r.logger.Debugf(ctx, "no registry pull secret configured")
+		return nil, nil
+	}
+
+	var dockerConfigJSON []byte
+	{
+		r.logger.Debugf(ctx, "finding secret %#q in namespace %#q", r.pullSecretName, r.pullSecretNamespace)
+
+		secret, err := r.k8sClient.CoreV1().Secrets(r.pullSecretNamespace).Get(ctx, r.pullSecretName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			// We keep the secret distributed before, if any, so that apps of
+			// existing tenant clusters can still pull their images.
+			r.logger.Debugf(ctx, "did not find secret %#q in namespace %#q", r.pullSecretName, r.pullSecretNamespace)
+			r.logger.Debugf(ctx, "canceling resource")
+			resourcecanceledcontext.SetCanceled(ctx)
+			return nil, nil
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "found secret %#q in namespace %#q", r.pullSecretName, r.pullSecretNamespace)
+
+		var ok bool
+		dockerConfigJSON, ok = secret.Data[corev1.DockerConfigJsonKey]
+		if !ok {
+			return nil, microerror.Maskf(invalidConfigError, "secret %#q in namespace %#q must contain %#q", r.pullSecretName, r.pullSecretNamespace, corev1.DockerConfigJsonKey)
+		}
+	}
+
+	values := map[string]interface{}{
+		"registry": map[string]interface{}{
+			"domain": r.registryDomain,
+			"pullSecret": map[string]interface{}{
+				"dockerConfigJSON": base64.StdEncoding.EncodeToString(dockerConfigJSON),
+			},
+		},
+	}
+
+	yamlValues, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.RegistryPullSecretName(&cr),
+			Namespace: key.ClusterID(&cr),
+			Annotations: map[string]string{
+				annotation.Notes: fmt.Sprintf("DO NOT EDIT. Values managed by %s.", project.Name()),
+			},
+			Labels: map[string]string{
+				label.Cluster:      key.ClusterID(&cr),
+				label.ManagedBy:    project.Name(),
+				label.Organization: key.OrganizationID(&cr),
+				label.ServiceType:  label.ServiceTypeManaged,
+			},
+		},
+		Data: map[string][]byte{
+			"values": yamlValues,
+		},
+	}
+
+	return []*corev1.Secret{secret}, nil
+}
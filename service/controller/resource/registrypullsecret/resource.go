@@ -0,0 +1,75 @@
+package registrypullsecret
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// Name is the identifier of the resource.
+	Name = "registrypullsecret"
+)
+
+// Config represents the configuration used to create a new registry pull
+// secret resource.
+type Config struct {
+	K8sClient kubernetes.Interface
+	Logger    micrologger.Logger
+
+	// PullSecretName is the name of the dockerconfigjson secret distributed to
+	// the apps of every tenant cluster. Nothing is distributed when empty.
+	PullSecretName      string
+	PullSecretNamespace string
+	RegistryDomain      string
+}
+
+// Resource implements the registry pull secret resource. It syncs the
+// configured pull secret into the namespace of every tenant cluster in the
+// form of app values, so that app-operator passes it on to the charts of the
+// managed apps.
+type Resource struct {
+	k8sClient kubernetes.Interface
+	logger    micrologger.Logger
+
+	pullSecretName      string
+	pullSecretNamespace string
+	registryDomain      string
+}
+
+// New creates a new configured secret state getter resource managing registry
+// pull secrets.
+//
+//     https://pkg.go.dev/github.com/giantswarm/operatorkit/v4/pkg/resource/k8s/secretresource#StateGetter
+//
+func New(config Config) (*Resource, error) {
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	if config.PullSecretName != "" && config.PullSecretNamespace == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.PullSecretNamespace must not be empty", config)
+	}
+	if config.RegistryDomain == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.RegistryDomain must not be empty", config)
+	}
+
+	r := &Resource{
+		k8sClient: config.K8sClient,
+		logger:    config.Logger,
+
+		pullSecretName:      config.PullSecretName,
+		pullSecretNamespace: config.PullSecretNamespace,
+		registryDomain:      config.RegistryDomain,
+	}
+
+	return r, nil
+}
+
+// Name returns name of the Resource.
+func (r *Resource) Name() string {
+	return Name
+}
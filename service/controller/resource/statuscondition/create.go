@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
 	"github.com/giantswarm/errors/tenant"
@@ -58,6 +59,8 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		r.logger.Debugf(ctx, "found cluster")
 	}
 
+	var tenantAPIAvailable bool
+
 	tenantClient, err := r.tenantClient.K8sClient(ctx, cr)
 	if tenantclient.IsNotAvailable(err) {
 		r.logger.Debugf(ctx, "tenant client is not available yet")
@@ -78,6 +81,7 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 			return microerror.Mask(err)
 		} else {
 			nodes = l.Items
+			tenantAPIAvailable = true
 
 			r.logger.Debugf(ctx, "found %d nodes from tenant cluster", len(nodes))
 		}
@@ -122,6 +126,54 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		return microerror.Mask(err)
 	}
 
+	{
+		componentVersions, err := r.releaseVersion.ComponentVersion(ctx, cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		desiredVersion := componentVersions[fmt.Sprintf("%s-operator", r.provider)]
+
+		condition := r.computeDegradedCondition(uc.GetCommonClusterStatus(), time.Now(), tenantAPIAvailable, nodes, cpList.Items, mdList.Items, desiredVersion)
+
+		err = r.ensureDegradedCondition(ctx, &cl, condition)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		// Without the tenant API we do not know about the nodes, so we keep the
+		// last observed upgrade progress.
+		if tenantAPIAvailable {
+			p := computeUpgradeProgress(nodes, cpList.Items, mdList.Items, desiredVersion, r.providerOperatorVersionLabel())
+
+			err = r.ensureUpgradeProgress(ctx, &cl, p)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+
+		// Releases without Kubernetes component leave nothing to compare
+		// against.
+		if tenantAPIAvailable && componentVersions[kubernetesComponent] != "" {
+			r.logger.Debugf(ctx, "finding server version of tenant cluster")
+
+			info, err := tenantClient.K8sClient().Discovery().ServerVersion()
+			if tenant.IsAPINotAvailable(err) {
+				r.logger.Debugf(ctx, "tenant API not available yet")
+			} else if err != nil {
+				return microerror.Mask(err)
+			} else {
+				r.logger.Debugf(ctx, "found server version %#q of tenant cluster", info.GitVersion)
+
+				kv, condition := computeKubernetesVersion(info.GitVersion, nodes, componentVersions[kubernetesComponent])
+
+				err = r.ensureKubernetesVersion(ctx, &cl, kv, condition)
+				if err != nil {
+					return microerror.Mask(err)
+				}
+			}
+		}
+	}
+
 	if !reflect.DeepEqual(cr.GetCommonClusterStatus(), uc.GetCommonClusterStatus()) {
 		r.logger.Debugf(ctx, "updating cluster status")
 
@@ -132,6 +184,13 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 
 		r.logger.Debugf(ctx, "updated cluster status")
 
+		// Transitions are only observed once the status completing them is
+		// stored, so that conflicting updates do not count them twice.
+		transition, duration, ok := completedTransition(cr.GetCommonClusterStatus(), uc.GetCommonClusterStatus())
+		if ok {
+			transitionDuration.WithLabelValues(transition, key.ReleaseVersion(uc), r.provider).Observe(duration.Seconds())
+		}
+
 		r.logger.Debugf(ctx, "canceling reconciliation")
 		reconciliationcanceledcontext.SetCanceled(ctx)
 
@@ -148,7 +207,7 @@ func (r *Resource) computeCreateClusterStatusConditions(ctx context.Context, cl
 	}
 
 	providerOperator := fmt.Sprintf("%s-operator", r.provider)
-	providerOperatorVersionLabel := fmt.Sprintf("%s-operator.giantswarm.io/version", r.provider)
+	providerOperatorVersionLabel := r.providerOperatorVersionLabel()
 
 	status := cr.GetCommonClusterStatus()
 
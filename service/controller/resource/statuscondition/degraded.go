@@ -0,0 +1,155 @@
+package statuscondition
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	apiv1alpha3 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+const (
+	degradedReasonMastersNotReady      = "MastersNotReady"
+	degradedReasonNodePoolNotReady     = "NodePoolNotReady"
+	degradedReasonNodeVersionMismatch  = "NodeVersionMismatch"
+	degradedReasonTenantAPIUnreachable = "TenantAPIUnreachable"
+	degradedReasonTransitionStalled    = "TransitionStalled"
+)
+
+// computeDegradedCondition returns the Degraded condition of a tenant cluster.
+// A tenant cluster is degraded when its creation or update did not finish
+// within the configured threshold. The reason of the condition names the first
+// problem found, checking the tenant API, the masters, the node pools and the
+// node versions in that order.
+func (r *Resource) computeDegradedCondition(status infrastructurev1alpha2.CommonClusterStatus, now time.Time, tenantAPIAvailable bool, nodes []corev1.Node, controlPlanes []infrastructurev1alpha2.G8sControlPlane, machineDeployments []apiv1alpha2.MachineDeployment, desiredVersion string) apiv1alpha3.Condition {
+	notDegraded := apiv1alpha3.Condition{
+		Type:   key.DegradedCondition,
+		Status: corev1.ConditionFalse,
+	}
+
+	var transition string
+	var since time.Time
+	var threshold time.Duration
+	switch status.LatestCondition() {
+	case infrastructurev1alpha2.ClusterStatusConditionCreating:
+		transition = transitionCreation
+		since = status.GetCreatingCondition().LastTransitionTime.Time
+		threshold = r.creationThreshold
+	case infrastructurev1alpha2.ClusterStatusConditionUpdating:
+		transition = transitionUpdate
+		since = status.GetUpdatingCondition().LastTransitionTime.Time
+		threshold = r.updateThreshold
+	default:
+		return notDegraded
+	}
+
+	if now.Before(since.Add(threshold)) {
+		return notDegraded
+	}
+
+	reason, message := degradedReason(tenantAPIAvailable, nodes, controlPlanes, machineDeployments, desiredVersion, r.providerOperatorVersionLabel())
+
+	return apiv1alpha3.Condition{
+		Type:    key.DegradedCondition,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: fmt.Sprintf("cluster %s did not finish within %s: %s", transition, threshold, message),
+	}
+}
+
+// ensureDegradedCondition stores the given Degraded condition in the
+// annotations of the given Cluster CR and emits events when the tenant cluster
+// becomes degraded or recovers.
+func (r *Resource) ensureDegradedCondition(ctx context.Context, cl *apiv1alpha2.Cluster, condition apiv1alpha3.Condition) error {
+	wasDegraded := key.IsConditionTrue(key.Conditions(cl), key.DegradedCondition)
+
+	conditions, changed := key.WithCondition(key.Conditions(cl), condition)
+	if !changed {
+		r.logger.Debugf(ctx, "condition %#q is up to date", condition.Type)
+		return nil
+	}
+
+	{
+		r.logger.Debugf(ctx, "updating condition %#q", condition.Type)
+
+		patch := client.MergeFrom(cl.DeepCopy())
+
+		a := cl.GetAnnotations()
+		if a == nil {
+			a = map[string]string{}
+		}
+		a[annotation.Conditions] = key.ConditionsAnnotation(conditions)
+		cl.SetAnnotations(a)
+
+		err := r.k8sClient.CtrlClient().Patch(ctx, cl, patch)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "updated condition %#q", condition.Type)
+	}
+
+	if condition.Status == corev1.ConditionTrue {
+		r.event.Warn(ctx, cl, condition.Reason, condition.Message)
+	} else if wasDegraded {
+		r.event.Emit(ctx, cl, "ClusterRecovered", "cluster is not degraded anymore")
+	}
+
+	return nil
+}
+
+func (r *Resource) providerOperatorVersionLabel() string {
+	return fmt.Sprintf("%s-operator.giantswarm.io/version", r.provider)
+}
+
+func degradedReason(tenantAPIAvailable bool, nodes []corev1.Node, controlPlanes []infrastructurev1alpha2.G8sControlPlane, machineDeployments []apiv1alpha2.MachineDeployment, desiredVersion string, versionLabel string) (string, string) {
+	if !tenantAPIAvailable {
+		return degradedReasonTenantAPIUnreachable, "tenant API is not reachable"
+	}
+
+	{
+		var desired, ready int
+		for _, cp := range controlPlanes {
+			desired += int(cp.Status.Replicas)
+			ready += int(cp.Status.ReadyReplicas)
+		}
+
+		if desired == 0 || ready < desired {
+			return degradedReasonMastersNotReady, fmt.Sprintf("%d of %d masters are ready", ready, desired)
+		}
+	}
+
+	for _, md := range machineDeployments {
+		desired := md.Status.Replicas
+		if md.Spec.Replicas != nil {
+			desired = *md.Spec.Replicas
+		}
+
+		if md.Status.ReadyReplicas < desired {
+			return degradedReasonNodePoolNotReady, fmt.Sprintf("%d of %d nodes of node pool %#q are ready", md.Status.ReadyReplicas, desired, key.MachineDeployment(&md))
+		}
+	}
+
+	{
+		var mismatching int
+		for _, n := range nodes {
+			if n.Labels[versionLabel] != desiredVersion {
+				mismatching++
+			}
+		}
+
+		if mismatching != 0 {
+			return degradedReasonNodeVersionMismatch, fmt.Sprintf("%d of %d nodes do not run version %#q", mismatching, len(nodes), desiredVersion)
+		}
+	}
+
+	return degradedReasonTransitionStalled, "no cause could be determined"
+}
@@ -0,0 +1,146 @@
+package statuscondition
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+
+	"github.com/giantswarm/cluster-operator/v3/service/internal/unittest"
+)
+
+func Test_computeDegradedCondition(t *testing.T) {
+	testCases := []struct {
+		name               string
+		conditions         []infrastructurev1alpha2.CommonClusterStatusCondition
+		updateThreshold    time.Duration
+		tenantAPIAvailable bool
+		masterReady        int32
+		nodePoolReady      int32
+		desiredVersion     string
+
+		expectStatus corev1.ConditionStatus
+		expectReason string
+	}{
+		{
+			name:               "case 0: update within threshold",
+			updateThreshold:    time.Hour,
+			tenantAPIAvailable: true,
+			masterReady:        1,
+			nodePoolReady:      1,
+			desiredVersion:     "8.7.5",
+
+			expectStatus: corev1.ConditionFalse,
+		},
+		{
+			name:               "case 1: tenant API unreachable",
+			updateThreshold:    10 * time.Minute,
+			tenantAPIAvailable: false,
+			masterReady:        1,
+			nodePoolReady:      1,
+			desiredVersion:     "8.7.5",
+
+			expectStatus: corev1.ConditionTrue,
+			expectReason: degradedReasonTenantAPIUnreachable,
+		},
+		{
+			name:               "case 2: masters not ready",
+			updateThreshold:    10 * time.Minute,
+			tenantAPIAvailable: true,
+			masterReady:        0,
+			nodePoolReady:      1,
+			desiredVersion:     "8.7.5",
+
+			expectStatus: corev1.ConditionTrue,
+			expectReason: degradedReasonMastersNotReady,
+		},
+		{
+			name:               "case 3: node pool not ready",
+			updateThreshold:    10 * time.Minute,
+			tenantAPIAvailable: true,
+			masterReady:        1,
+			nodePoolReady:      0,
+			desiredVersion:     "8.7.5",
+
+			expectStatus: corev1.ConditionTrue,
+			expectReason: degradedReasonNodePoolNotReady,
+		},
+		{
+			name:               "case 4: node version mismatch",
+			updateThreshold:    10 * time.Minute,
+			tenantAPIAvailable: true,
+			masterReady:        1,
+			nodePoolReady:      1,
+			desiredVersion:     "8.7.6",
+
+			expectStatus: corev1.ConditionTrue,
+			expectReason: degradedReasonNodeVersionMismatch,
+		},
+		{
+			name:               "case 5: stalled without known cause",
+			updateThreshold:    10 * time.Minute,
+			tenantAPIAvailable: true,
+			masterReady:        1,
+			nodePoolReady:      1,
+			desiredVersion:     "8.7.5",
+
+			expectStatus: corev1.ConditionTrue,
+			expectReason: degradedReasonTransitionStalled,
+		},
+		{
+			name: "case 6: update finished",
+			conditions: []infrastructurev1alpha2.CommonClusterStatusCondition{
+				{
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-5 * time.Minute)),
+					Condition:          infrastructurev1alpha2.ClusterStatusConditionUpdated,
+				},
+				{
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-15 * time.Minute)),
+					Condition:          infrastructurev1alpha2.ClusterStatusConditionUpdating,
+				},
+			},
+			updateThreshold:    10 * time.Minute,
+			tenantAPIAvailable: false,
+			desiredVersion:     "8.7.5",
+
+			expectStatus: corev1.ConditionFalse,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			r := Resource{
+				creationThreshold: 30 * time.Minute,
+				provider:          "aws",
+				updateThreshold:   tc.updateThreshold,
+			}
+
+			cl := unittest.DefaultCluster()
+			status := cl.GetCommonClusterStatus()
+			if tc.conditions != nil {
+				status.Conditions = tc.conditions
+			}
+
+			cp := unittest.DefaultControlPlane()
+			cp.Status.ReadyReplicas = tc.masterReady
+
+			md := unittest.DefaultMachineDeployment()
+			md.Status.ReadyReplicas = tc.nodePoolReady
+
+			nodes := []corev1.Node{unittest.NewMasterNode(), unittest.NewWorkerNode()}
+
+			condition := r.computeDegradedCondition(status, time.Now(), tc.tenantAPIAvailable, nodes, []infrastructurev1alpha2.G8sControlPlane{cp}, []apiv1alpha2.MachineDeployment{md}, tc.desiredVersion)
+
+			if condition.Status != tc.expectStatus {
+				t.Fatalf("expected %#q to be equal to %#q", tc.expectStatus, condition.Status)
+			}
+			if condition.Reason != tc.expectReason {
+				t.Fatalf("expected %#q to be equal to %#q", tc.expectReason, condition.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package statuscondition
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	apiv1alpha3 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+const (
+	kubernetesComponent = "kubernetes"
+)
+
+// computeKubernetesVersion returns the Kubernetes versions reported by the
+// tenant API server and the kubelets of the given nodes, compared against the
+// desired Kubernetes version of the release. The returned condition is true
+// when any of them runs another version.
+func computeKubernetesVersion(serverVersion string, nodes []corev1.Node, desiredVersion string) (key.KubernetesVersion, apiv1alpha3.Condition) {
+	kv := key.KubernetesVersion{
+		Desired:         desiredVersion,
+		Kubelets:        map[string]int{},
+		Server:          serverVersion,
+		ServerMinorSkew: minorSkew(serverVersion, desiredVersion),
+	}
+
+	var skewedKubelets int
+	for i, n := range nodes {
+		v := n.Status.NodeInfo.KubeletVersion
+		kv.Kubelets[v]++
+
+		s := minorSkew(v, desiredVersion)
+		if i == 0 || s > kv.KubeletMinorSkew {
+			kv.KubeletMinorSkew = s
+		}
+
+		if !sameKubernetesVersion(v, desiredVersion) {
+			skewedKubelets++
+		}
+	}
+
+	var skewed []string
+	if !sameKubernetesVersion(serverVersion, desiredVersion) {
+		skewed = append(skewed, fmt.Sprintf("API server runs version %#q", serverVersion))
+	}
+	if skewedKubelets != 0 {
+		skewed = append(skewed, fmt.Sprintf("%d of %d kubelets run other versions", skewedKubelets, len(nodes)))
+	}
+
+	if len(skewed) == 0 {
+		return kv, apiv1alpha3.Condition{
+			Type:   key.KubernetesVersionSkewedCondition,
+			Status: corev1.ConditionFalse,
+		}
+	}
+
+	return kv, apiv1alpha3.Condition{
+		Type:    key.KubernetesVersionSkewedCondition,
+		Status:  corev1.ConditionTrue,
+		Reason:  "KubernetesVersionSkewed",
+		Message: fmt.Sprintf("release defines Kubernetes version %#q but %s", desiredVersion, strings.Join(skewed, " and ")),
+	}
+}
+
+// ensureKubernetesVersion stores the given Kubernetes versions and the
+// KubernetesVersionSkewed condition in the annotations of the given Cluster
+// CR.
+func (r *Resource) ensureKubernetesVersion(ctx context.Context, cl *apiv1alpha2.Cluster, kv key.KubernetesVersion, condition apiv1alpha3.Condition) error {
+	// Empty kubelet versions are omitted from the annotation, so we compare the
+	// annotation values.
+	versionChanged := cl.GetAnnotations()[annotation.KubernetesVersion] != key.KubernetesVersionAnnotation(kv)
+
+	conditions, conditionChanged := key.WithCondition(key.Conditions(cl), condition)
+	if !versionChanged && !conditionChanged {
+		r.logger.Debugf(ctx, "condition %#q is up to date", condition.Type)
+		return nil
+	}
+
+	{
+		r.logger.Debugf(ctx, "updating condition %#q", condition.Type)
+
+		patch := client.MergeFrom(cl.DeepCopy())
+
+		a := cl.GetAnnotations()
+		if a == nil {
+			a = map[string]string{}
+		}
+		a[annotation.Conditions] = key.ConditionsAnnotation(conditions)
+		a[annotation.KubernetesVersion] = key.KubernetesVersionAnnotation(kv)
+		cl.SetAnnotations(a)
+
+		err := r.k8sClient.CtrlClient().Patch(ctx, cl, patch)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "updated condition %#q", condition.Type)
+	}
+
+	return nil
+}
+
+// minorSkew returns the number of minor versions the given version is behind
+// the desired version. Versions which cannot be parsed or have another major
+// version are not considered skewed.
+func minorSkew(v string, desired string) int {
+	a, err := version.ParseGeneric(v)
+	if err != nil {
+		return 0
+	}
+	d, err := version.ParseGeneric(desired)
+	if err != nil {
+		return 0
+	}
+
+	if a.Major() != d.Major() {
+		return 0
+	}
+
+	return int(d.Minor()) - int(a.Minor())
+}
+
+// sameKubernetesVersion returns whether the given versions have the same
+// major, minor and patch version. Provider specific suffixes and the v prefix
+// are ignored.
+func sameKubernetesVersion(a string, b string) bool {
+	va, err := version.ParseGeneric(a)
+	if err != nil {
+		return strings.TrimPrefix(a, "v") == strings.TrimPrefix(b, "v")
+	}
+	vb, err := version.ParseGeneric(b)
+	if err != nil {
+		return false
+	}
+
+	return va.Major() == vb.Major() && va.Minor() == vb.Minor() && va.Patch() == vb.Patch()
+}
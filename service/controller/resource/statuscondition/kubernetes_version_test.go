@@ -0,0 +1,136 @@
+package statuscondition
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/unittest"
+)
+
+func Test_computeKubernetesVersion(t *testing.T) {
+	testCases := []struct {
+		name            string
+		serverVersion   string
+		kubeletVersions []string
+		desiredVersion  string
+
+		expectVersion key.KubernetesVersion
+		expectStatus  corev1.ConditionStatus
+	}{
+		{
+			name:            "case 0: all on the desired version",
+			serverVersion:   "v1.18.5",
+			kubeletVersions: []string{"v1.18.5", "v1.18.5"},
+			desiredVersion:  "1.18.5",
+
+			expectVersion: key.KubernetesVersion{
+				Desired:  "1.18.5",
+				Kubelets: map[string]int{"v1.18.5": 2},
+				Server:   "v1.18.5",
+			},
+			expectStatus: corev1.ConditionFalse,
+		},
+		{
+			name:            "case 1: upgrade did not change the API server",
+			serverVersion:   "v1.17.9",
+			kubeletVersions: []string{"v1.17.9", "v1.18.5"},
+			desiredVersion:  "1.18.5",
+
+			expectVersion: key.KubernetesVersion{
+				Desired:          "1.18.5",
+				Kubelets:         map[string]int{"v1.17.9": 1, "v1.18.5": 1},
+				KubeletMinorSkew: 1,
+				Server:           "v1.17.9",
+				ServerMinorSkew:  1,
+			},
+			expectStatus: corev1.ConditionTrue,
+		},
+		{
+			name:            "case 2: patch version skew and provider suffix",
+			serverVersion:   "v1.18.5-eks-1",
+			kubeletVersions: []string{"v1.18.4"},
+			desiredVersion:  "1.18.5",
+
+			expectVersion: key.KubernetesVersion{
+				Desired:  "1.18.5",
+				Kubelets: map[string]int{"v1.18.4": 1},
+				Server:   "v1.18.5-eks-1",
+			},
+			expectStatus: corev1.ConditionTrue,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var nodes []corev1.Node
+			for _, v := range tc.kubeletVersions {
+				n := unittest.NewWorkerNode()
+				n.Status.NodeInfo.KubeletVersion = v
+				nodes = append(nodes, n)
+			}
+
+			kv, condition := computeKubernetesVersion(tc.serverVersion, nodes, tc.desiredVersion)
+
+			if !reflect.DeepEqual(kv, tc.expectVersion) {
+				t.Fatalf("expected %#v to be equal to %#v", tc.expectVersion, kv)
+			}
+			if condition.Status != tc.expectStatus {
+				t.Fatalf("expected %#q to be equal to %#q", tc.expectStatus, condition.Status)
+			}
+		})
+	}
+}
+
+func Test_Resource_ensureKubernetesVersion(t *testing.T) {
+	testCases := []struct {
+		name            string
+		kubeletVersions []string
+	}{
+		{
+			name:            "case 0: cluster with nodes",
+			kubeletVersions: []string{"v1.18.5", "v1.18.4"},
+		},
+		{
+			name:            "case 1: cluster without nodes",
+			kubeletVersions: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			ctx := context.Background()
+
+			var nodes []corev1.Node
+			for _, v := range tc.kubeletVersions {
+				n := unittest.NewWorkerNode()
+				n.Status.NodeInfo.KubeletVersion = v
+				nodes = append(nodes, n)
+			}
+
+			kv, condition := computeKubernetesVersion("v1.18.5", nodes, "1.18.5")
+
+			r, c := newPatchCountingResource(t)
+
+			cl := getCluster(ctx, t, c)
+			err := r.ensureKubernetesVersion(ctx, &cl, kv, condition)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cl = getCluster(ctx, t, c)
+			err = r.ensureKubernetesVersion(ctx, &cl, kv, condition)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if c.patches != 1 {
+				t.Fatalf("expected %d to be equal to %d", 1, c.patches)
+			}
+		})
+	}
+}
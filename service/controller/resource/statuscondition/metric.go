@@ -0,0 +1,22 @@
+package statuscondition
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	transitionDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "cluster_operator",
+			Subsystem: "cluster",
+			Name:      "transition_duration_seconds",
+			Help:      "Duration of completed tenant cluster creations and updates.",
+			Buckets:   prometheus.ExponentialBuckets(60, 2, 10),
+		},
+		[]string{"transition", "release_version", "provider"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(transitionDuration)
+}
@@ -0,0 +1,103 @@
+package statuscondition
+
+import (
+	"context"
+
+	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+// computeUpgradeProgress returns the progress of the given nodes towards the
+// desired version. Master nodes are accounted to the control plane and worker
+// nodes to the node pool named by their MachineDeployment label. Every node
+// pool of the tenant cluster is listed, even if none of its nodes joined yet.
+func computeUpgradeProgress(nodes []corev1.Node, controlPlanes []infrastructurev1alpha2.G8sControlPlane, machineDeployments []apiv1alpha2.MachineDeployment, desiredVersion string, versionLabel string) key.UpgradeProgress {
+	p := key.UpgradeProgress{
+		NodePools: map[string]key.NodeProgress{},
+		Version:   desiredVersion,
+	}
+
+	for _, cp := range controlPlanes {
+		p.ControlPlane.Desired += int(cp.Status.Replicas)
+	}
+
+	for _, md := range machineDeployments {
+		md := md // dereferencing pointer value into new scope
+
+		desired := md.Status.Replicas
+		if md.Spec.Replicas != nil {
+			desired = *md.Spec.Replicas
+		}
+
+		np := p.NodePools[key.MachineDeployment(&md)]
+		np.Desired += int(desired)
+		p.NodePools[key.MachineDeployment(&md)] = np
+	}
+
+	for _, n := range nodes {
+		if _, ok := n.Labels[label.MasterNodeRole]; ok {
+			p.ControlPlane = withNode(p.ControlPlane, n, desiredVersion, versionLabel)
+		} else if id, ok := n.Labels[label.MachineDeployment]; ok {
+			p.NodePools[id] = withNode(p.NodePools[id], n, desiredVersion, versionLabel)
+		}
+	}
+
+	return p
+}
+
+// ensureUpgradeProgress stores the given upgrade progress in the annotations
+// of the given Cluster CR.
+func (r *Resource) ensureUpgradeProgress(ctx context.Context, cl *apiv1alpha2.Cluster, p key.UpgradeProgress) error {
+	// Empty node pools are omitted from the annotation, so we compare the
+	// annotation values.
+	if cl.GetAnnotations()[annotation.UpgradeProgress] == key.UpgradeProgressAnnotation(p) {
+		r.logger.Debugf(ctx, "upgrade progress is up to date")
+		return nil
+	}
+
+	{
+		r.logger.Debugf(ctx, "updating upgrade progress")
+
+		patch := client.MergeFrom(cl.DeepCopy())
+
+		a := cl.GetAnnotations()
+		if a == nil {
+			a = map[string]string{}
+		}
+		a[annotation.UpgradeProgress] = key.UpgradeProgressAnnotation(p)
+		cl.SetAnnotations(a)
+
+		err := r.k8sClient.CtrlClient().Patch(ctx, cl, patch)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "updated upgrade progress")
+	}
+
+	return nil
+}
+
+// withNode returns the given progress with the given node accounted.
+func withNode(np key.NodeProgress, n corev1.Node, desiredVersion string, versionLabel string) key.NodeProgress {
+	if n.Labels[versionLabel] == desiredVersion {
+		np.NewVersion++
+	} else {
+		np.OldVersion++
+	}
+
+	for _, c := range n.Status.Conditions {
+		if c.Type == corev1.NodeReady && c.Status == corev1.ConditionTrue {
+			np.Ready++
+		}
+	}
+
+	return np
+}
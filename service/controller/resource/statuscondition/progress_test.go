@@ -0,0 +1,207 @@
+package statuscondition
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+
+	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
+	"github.com/giantswarm/k8sclient/v5/pkg/k8sclienttest"
+	"github.com/giantswarm/micrologger/microloggertest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/unittest"
+)
+
+func Test_computeUpgradeProgress(t *testing.T) {
+	testCases := []struct {
+		name           string
+		nodes          func() []corev1.Node
+		desiredVersion string
+
+		expectProgress key.UpgradeProgress
+	}{
+		{
+			name: "case 0: all nodes on the desired version",
+			nodes: func() []corev1.Node {
+				return []corev1.Node{unittest.NewMasterNode(), newWorkerNode("a1b2c", "8.7.5", true), newWorkerNode("a1b2c", "8.7.5", true)}
+			},
+			desiredVersion: "8.7.5",
+
+			expectProgress: key.UpgradeProgress{
+				ControlPlane: key.NodeProgress{Desired: 1, NewVersion: 1, Ready: 1},
+				NodePools: map[string]key.NodeProgress{
+					"a1b2c": {Desired: 2, NewVersion: 2, Ready: 2},
+				},
+				Version: "8.7.5",
+			},
+		},
+		{
+			name: "case 1: rolling node pool",
+			nodes: func() []corev1.Node {
+				return []corev1.Node{unittest.NewMasterNode(), newWorkerNode("a1b2c", "8.7.5", true), newWorkerNode("a1b2c", "8.7.6", false)}
+			},
+			desiredVersion: "8.7.6",
+
+			expectProgress: key.UpgradeProgress{
+				ControlPlane: key.NodeProgress{Desired: 1, OldVersion: 1, Ready: 1},
+				NodePools: map[string]key.NodeProgress{
+					"a1b2c": {Desired: 2, NewVersion: 1, OldVersion: 1, Ready: 1},
+				},
+				Version: "8.7.6",
+			},
+		},
+		{
+			name: "case 2: node pool without nodes",
+			nodes: func() []corev1.Node {
+				return []corev1.Node{unittest.NewMasterNode()}
+			},
+			desiredVersion: "8.7.5",
+
+			expectProgress: key.UpgradeProgress{
+				ControlPlane: key.NodeProgress{Desired: 1, NewVersion: 1, Ready: 1},
+				NodePools: map[string]key.NodeProgress{
+					"a1b2c": {Desired: 2},
+				},
+				Version: "8.7.5",
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			cp := unittest.DefaultControlPlane()
+
+			replicas := int32(2)
+			md := unittest.DefaultMachineDeployment()
+			md.Labels = map[string]string{label.MachineDeployment: "a1b2c"}
+			md.Spec.Replicas = &replicas
+
+			p := computeUpgradeProgress(tc.nodes(), []infrastructurev1alpha2.G8sControlPlane{cp}, []apiv1alpha2.MachineDeployment{md}, tc.desiredVersion, "aws-operator.giantswarm.io/version")
+
+			if !reflect.DeepEqual(p, tc.expectProgress) {
+				t.Fatalf("expected %#v to be equal to %#v", tc.expectProgress, p)
+			}
+		})
+	}
+}
+
+func Test_Resource_ensureUpgradeProgress(t *testing.T) {
+	testCases := []struct {
+		name     string
+		progress key.UpgradeProgress
+	}{
+		{
+			name: "case 0: cluster with node pools",
+			progress: key.UpgradeProgress{
+				ControlPlane: key.NodeProgress{Desired: 1, NewVersion: 1, Ready: 1},
+				NodePools: map[string]key.NodeProgress{
+					"a1b2c": {Desired: 2, NewVersion: 2, Ready: 2},
+				},
+				Version: "8.7.5",
+			},
+		},
+		{
+			name: "case 1: cluster without node pools",
+			progress: key.UpgradeProgress{
+				ControlPlane: key.NodeProgress{Desired: 1, NewVersion: 1, Ready: 1},
+				NodePools:    map[string]key.NodeProgress{},
+				Version:      "8.7.5",
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			ctx := context.Background()
+
+			r, c := newPatchCountingResource(t)
+
+			cl := getCluster(ctx, t, c)
+			err := r.ensureUpgradeProgress(ctx, &cl, tc.progress)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cl = getCluster(ctx, t, c)
+			err = r.ensureUpgradeProgress(ctx, &cl, tc.progress)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if c.patches != 1 {
+				t.Fatalf("expected %d to be equal to %d", 1, c.patches)
+			}
+		})
+	}
+}
+
+// patchCountingClient counts the patches of the wrapped client.
+type patchCountingClient struct {
+	client.Client
+
+	patches int
+}
+
+func (c *patchCountingClient) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.patches++
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func getCluster(ctx context.Context, t *testing.T, c client.Client) apiv1alpha2.Cluster {
+	var cl apiv1alpha2.Cluster
+	err := c.Get(ctx, types.NamespacedName{Name: "a2wax", Namespace: "default"}, &cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cl
+}
+
+func newPatchCountingResource(t *testing.T) (*Resource, *patchCountingClient) {
+	scheme := runtime.NewScheme()
+	err := apiv1alpha2.AddToScheme(scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cl := &apiv1alpha2.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "a2wax",
+			Namespace: "default",
+		},
+	}
+
+	c := &patchCountingClient{
+		Client: fake.NewFakeClientWithScheme(scheme, cl),
+	}
+
+	r := &Resource{
+		k8sClient: k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+			CtrlClient: c,
+		}),
+		logger: microloggertest.New(),
+	}
+
+	return r, c
+}
+
+func newWorkerNode(machineDeployment string, version string, ready bool) corev1.Node {
+	n := unittest.NewWorkerNode()
+	n.Labels[label.MachineDeployment] = machineDeployment
+	n.Labels["aws-operator.giantswarm.io/version"] = version
+	if !ready {
+		n.Status.Conditions[0].Status = corev1.ConditionFalse
+	}
+
+	return n
+}
@@ -1,6 +1,8 @@
 package statuscondition
 
 import (
+	"time"
+
 	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
 	"github.com/giantswarm/k8sclient/v5/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
@@ -22,8 +24,14 @@ type Config struct {
 	ReleaseVersion releaseversion.Interface
 	TenantClient   tenantclient.Interface
 
+	// CreationThreshold is the duration after which a tenant cluster still
+	// being created is considered degraded.
+	CreationThreshold          time.Duration
 	NewCommonClusterObjectFunc func() infrastructurev1alpha2.CommonClusterObject
 	Provider                   string
+	// UpdateThreshold is the duration after which a tenant cluster still being
+	// updated is considered degraded.
+	UpdateThreshold time.Duration
 }
 
 type Resource struct {
@@ -33,8 +41,10 @@ type Resource struct {
 	releaseVersion releaseversion.Interface
 	tenantClient   tenantclient.Interface
 
+	creationThreshold          time.Duration
 	newCommonClusterObjectFunc func() infrastructurev1alpha2.CommonClusterObject
 	provider                   string
+	updateThreshold            time.Duration
 }
 
 func New(config Config) (*Resource, error) {
@@ -54,12 +64,18 @@ func New(config Config) (*Resource, error) {
 		return nil, microerror.Maskf(invalidConfigError, "%T.TenantClient must not be empty", config)
 	}
 
+	if config.CreationThreshold == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CreationThreshold must not be empty", config)
+	}
 	if config.NewCommonClusterObjectFunc == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.NewCommonClusterObjectFunc must not be empty", config)
 	}
 	if config.Provider == "" {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Provider must not be empty", config)
 	}
+	if config.UpdateThreshold == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.UpdateThreshold must not be empty", config)
+	}
 
 	r := &Resource{
 		event:          config.Event,
@@ -68,8 +84,10 @@ func New(config Config) (*Resource, error) {
 		releaseVersion: config.ReleaseVersion,
 		tenantClient:   config.TenantClient,
 
+		creationThreshold:          config.CreationThreshold,
 		newCommonClusterObjectFunc: config.NewCommonClusterObjectFunc,
 		provider:                   config.Provider,
+		updateThreshold:            config.UpdateThreshold,
 	}
 
 	return r, nil
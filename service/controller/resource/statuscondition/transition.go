@@ -0,0 +1,47 @@
+package statuscondition
+
+import (
+	"time"
+
+	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+const (
+	transitionCreation = "creation"
+	transitionUpdate   = "update"
+)
+
+// completedTransition returns the creation or update of a tenant cluster the
+// new status completes compared to the old status, together with its duration.
+// The duration is measured between the Creating and Created or the Updating
+// and Updated conditions.
+func completedTransition(old, new infrastructurev1alpha2.CommonClusterStatus) (string, time.Duration, bool) {
+	latest := key.LatestClusterStatusCondition(new.Conditions, "")
+	{
+		previous := key.LatestClusterStatusCondition(old.Conditions, "")
+		if latest.Condition == previous.Condition && latest.LastTransitionTime.Equal(&previous.LastTransitionTime) {
+			return "", 0, false
+		}
+	}
+
+	var transition string
+	var start infrastructurev1alpha2.CommonClusterStatusCondition
+	switch latest.Condition {
+	case infrastructurev1alpha2.ClusterStatusConditionCreated:
+		transition = transitionCreation
+		start = key.LatestClusterStatusCondition(new.Conditions, infrastructurev1alpha2.ClusterStatusConditionCreating)
+	case infrastructurev1alpha2.ClusterStatusConditionUpdated:
+		transition = transitionUpdate
+		start = key.LatestClusterStatusCondition(new.Conditions, infrastructurev1alpha2.ClusterStatusConditionUpdating)
+	default:
+		return "", 0, false
+	}
+
+	if start.LastTransitionTime.IsZero() {
+		return "", 0, false
+	}
+
+	return transition, latest.LastTransitionTime.Sub(start.LastTransitionTime.Time), true
+}
@@ -0,0 +1,111 @@
+package statuscondition
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_completedTransition(t *testing.T) {
+	now := time.Date(2020, 12, 1, 12, 0, 0, 0, time.UTC)
+
+	condition := func(c string, ago time.Duration) infrastructurev1alpha2.CommonClusterStatusCondition {
+		return infrastructurev1alpha2.CommonClusterStatusCondition{
+			Condition:          c,
+			LastTransitionTime: metav1.NewTime(now.Add(-ago)),
+		}
+	}
+
+	creating := condition(infrastructurev1alpha2.ClusterStatusConditionCreating, 3*time.Hour)
+	created := condition(infrastructurev1alpha2.ClusterStatusConditionCreated, 150*time.Minute)
+	updating := condition(infrastructurev1alpha2.ClusterStatusConditionUpdating, time.Hour)
+	updated := condition(infrastructurev1alpha2.ClusterStatusConditionUpdated, 0)
+
+	testCases := []struct {
+		name          string
+		oldConditions []infrastructurev1alpha2.CommonClusterStatusCondition
+		newConditions []infrastructurev1alpha2.CommonClusterStatusCondition
+
+		expectTransition string
+		expectDuration   time.Duration
+		expectCompleted  bool
+	}{
+		{
+			name:          "case 0: creation started",
+			oldConditions: nil,
+			newConditions: []infrastructurev1alpha2.CommonClusterStatusCondition{creating},
+
+			expectCompleted: false,
+		},
+		{
+			name:          "case 1: creation completed",
+			oldConditions: []infrastructurev1alpha2.CommonClusterStatusCondition{creating},
+			newConditions: []infrastructurev1alpha2.CommonClusterStatusCondition{created, creating},
+
+			expectTransition: transitionCreation,
+			expectDuration:   30 * time.Minute,
+			expectCompleted:  true,
+		},
+		{
+			name:          "case 2: creation completed before",
+			oldConditions: []infrastructurev1alpha2.CommonClusterStatusCondition{created, creating},
+			newConditions: []infrastructurev1alpha2.CommonClusterStatusCondition{created, creating},
+
+			expectCompleted: false,
+		},
+		{
+			name:          "case 3: update started",
+			oldConditions: []infrastructurev1alpha2.CommonClusterStatusCondition{created, creating},
+			newConditions: []infrastructurev1alpha2.CommonClusterStatusCondition{updating, created, creating},
+
+			expectCompleted: false,
+		},
+		{
+			name:          "case 4: update completed",
+			oldConditions: []infrastructurev1alpha2.CommonClusterStatusCondition{updating, created, creating},
+			newConditions: []infrastructurev1alpha2.CommonClusterStatusCondition{updated, updating, created, creating},
+
+			expectTransition: transitionUpdate,
+			expectDuration:   time.Hour,
+			expectCompleted:  true,
+		},
+		{
+			name:          "case 5: second update completed",
+			oldConditions: []infrastructurev1alpha2.CommonClusterStatusCondition{condition(infrastructurev1alpha2.ClusterStatusConditionUpdating, 20*time.Minute), condition(infrastructurev1alpha2.ClusterStatusConditionUpdated, 50*time.Minute), updating},
+			newConditions: []infrastructurev1alpha2.CommonClusterStatusCondition{updated, condition(infrastructurev1alpha2.ClusterStatusConditionUpdating, 20*time.Minute), condition(infrastructurev1alpha2.ClusterStatusConditionUpdated, 50*time.Minute), updating},
+
+			expectTransition: transitionUpdate,
+			expectDuration:   20 * time.Minute,
+			expectCompleted:  true,
+		},
+		{
+			name:          "case 6: created without creating condition",
+			oldConditions: nil,
+			newConditions: []infrastructurev1alpha2.CommonClusterStatusCondition{created},
+
+			expectCompleted: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			old := infrastructurev1alpha2.CommonClusterStatus{Conditions: tc.oldConditions}
+			new := infrastructurev1alpha2.CommonClusterStatus{Conditions: tc.newConditions}
+
+			transition, duration, completed := completedTransition(old, new)
+
+			if completed != tc.expectCompleted {
+				t.Fatalf("completed == %t, want %t", completed, tc.expectCompleted)
+			}
+			if transition != tc.expectTransition {
+				t.Fatalf("transition == %#q, want %#q", transition, tc.expectTransition)
+			}
+			if duration != tc.expectDuration {
+				t.Fatalf("duration == %s, want %s", duration, tc.expectDuration)
+			}
+		})
+	}
+}
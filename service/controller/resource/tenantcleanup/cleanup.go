@@ -0,0 +1,219 @@
+package tenantcleanup
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// storageProvisionerAnnotation is set on PersistentVolumeClaims which are
+	// dynamically provisioned by a storage provisioner.
+	storageProvisionerAnnotation = "volume.kubernetes.io/storage-provisioner"
+	// betaStorageProvisionerAnnotation is the deprecated version of
+	// storageProvisionerAnnotation, which is still set by Kubernetes versions
+	// up to 1.22.
+	betaStorageProvisionerAnnotation = "volume.beta.kubernetes.io/storage-provisioner"
+	// provisionedByAnnotation is set on PersistentVolumes which are dynamically
+	// provisioned by a storage provisioner.
+	provisionedByAnnotation = "pv.kubernetes.io/provisioned-by"
+
+	// pvcProtectionFinalizer keeps PersistentVolumeClaims from being deleted
+	// as long as Pods use them.
+	pvcProtectionFinalizer = "kubernetes.io/pvc-protection"
+)
+
+// cleanup requests the deletion of all LoadBalancer Services and dynamically
+// provisioned PersistentVolumeClaims of the tenant cluster and returns the
+// number of them and of the dynamically provisioned PersistentVolumes still
+// existing afterwards. Pods using claims which are being deleted are deleted,
+// so that they do not block the deletion of the claims.
+func cleanup(ctx context.Context, k8sClient kubernetes.Interface) (int, error) {
+	services, err := loadBalancerServices(ctx, k8sClient)
+	if err != nil {
+		return 0, microerror.Mask(err)
+	}
+
+	for _, s := range services {
+		if s.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		err = k8sClient.CoreV1().Services(s.Namespace).Delete(ctx, s.Name, metav1.DeleteOptions{})
+		if apierrors.IsNotFound(err) {
+			// fall through
+		} else if err != nil {
+			return 0, microerror.Mask(err)
+		}
+	}
+
+	pvcs, err := provisionedPersistentVolumeClaims(ctx, k8sClient)
+	if err != nil {
+		return 0, microerror.Mask(err)
+	}
+
+	for _, p := range pvcs {
+		if p.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		err = k8sClient.CoreV1().PersistentVolumeClaims(p.Namespace).Delete(ctx, p.Name, metav1.DeleteOptions{})
+		if apierrors.IsNotFound(err) {
+			// fall through
+		} else if err != nil {
+			return 0, microerror.Mask(err)
+		}
+	}
+
+	// Claims which are being deleted are kept by the pvc-protection finalizer
+	// as long as Pods use them. The claims are deleted before the Pods, so that
+	// Pods recreated by their controllers cannot use the claims anymore.
+	pvcs, err = provisionedPersistentVolumeClaims(ctx, k8sClient)
+	if err != nil {
+		return 0, microerror.Mask(err)
+	}
+
+	err = deletePodsUsingClaims(ctx, k8sClient, protectedPersistentVolumeClaims(pvcs))
+	if err != nil {
+		return 0, microerror.Mask(err)
+	}
+
+	// Cloud resources are deleted asynchronously by the tenant cluster, so the
+	// objects may still exist after requesting their deletion. The cloud
+	// volumes only get deleted with the volumes once their claims are gone.
+	services, err = loadBalancerServices(ctx, k8sClient)
+	if err != nil {
+		return 0, microerror.Mask(err)
+	}
+	pvcs, err = provisionedPersistentVolumeClaims(ctx, k8sClient)
+	if err != nil {
+		return 0, microerror.Mask(err)
+	}
+	pvs, err := provisionedPersistentVolumes(ctx, k8sClient)
+	if err != nil {
+		return 0, microerror.Mask(err)
+	}
+
+	return len(services) + len(pvcs) + len(pvs), nil
+}
+
+// deletePodsUsingClaims deletes the Pods which use any of the given
+// PersistentVolumeClaims.
+func deletePodsUsingClaims(ctx context.Context, k8sClient kubernetes.Interface, pvcs []corev1.PersistentVolumeClaim) error {
+	claims := map[string]map[string]bool{}
+	for _, p := range pvcs {
+		if claims[p.Namespace] == nil {
+			claims[p.Namespace] = map[string]bool{}
+		}
+		claims[p.Namespace][p.Name] = true
+	}
+
+	for namespace, names := range claims {
+		list, err := k8sClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		for _, pod := range list.Items {
+			if pod.GetDeletionTimestamp() != nil || !usesClaims(pod, names) {
+				continue
+			}
+
+			err = k8sClient.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+			if apierrors.IsNotFound(err) {
+				// fall through
+			} else if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func loadBalancerServices(ctx context.Context, k8sClient kubernetes.Interface) ([]corev1.Service, error) {
+	list, err := k8sClient.CoreV1().Services(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var services []corev1.Service
+	for _, s := range list.Items {
+		if s.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			services = append(services, s)
+		}
+	}
+
+	return services, nil
+}
+
+func provisionedPersistentVolumeClaims(ctx context.Context, k8sClient kubernetes.Interface) ([]corev1.PersistentVolumeClaim, error) {
+	list, err := k8sClient.CoreV1().PersistentVolumeClaims(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var pvcs []corev1.PersistentVolumeClaim
+	for _, p := range list.Items {
+		_, ok := p.Annotations[storageProvisionerAnnotation]
+		_, beta := p.Annotations[betaStorageProvisionerAnnotation]
+		if ok || beta {
+			pvcs = append(pvcs, p)
+		}
+	}
+
+	return pvcs, nil
+}
+
+// provisionedPersistentVolumes returns the dynamically provisioned
+// PersistentVolumes whose cloud volumes are deleted together with them.
+func provisionedPersistentVolumes(ctx context.Context, k8sClient kubernetes.Interface) ([]corev1.PersistentVolume, error) {
+	list, err := k8sClient.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var pvs []corev1.PersistentVolume
+	for _, p := range list.Items {
+		_, ok := p.Annotations[provisionedByAnnotation]
+		if ok && p.Spec.PersistentVolumeReclaimPolicy == corev1.PersistentVolumeReclaimDelete {
+			pvs = append(pvs, p)
+		}
+	}
+
+	return pvs, nil
+}
+
+// protectedPersistentVolumeClaims returns the given PersistentVolumeClaims
+// which are being deleted but are kept by the pvc-protection finalizer.
+func protectedPersistentVolumeClaims(pvcs []corev1.PersistentVolumeClaim) []corev1.PersistentVolumeClaim {
+	var protected []corev1.PersistentVolumeClaim
+	for _, p := range pvcs {
+		if p.GetDeletionTimestamp() == nil {
+			continue
+		}
+
+		for _, f := range p.GetFinalizers() {
+			if f == pvcProtectionFinalizer {
+				protected = append(protected, p)
+				break
+			}
+		}
+	}
+
+	return protected
+}
+
+func usesClaims(pod corev1.Pod, claims map[string]bool) bool {
+	for _, v := range pod.Spec.Volumes {
+		if v.PersistentVolumeClaim != nil && claims[v.PersistentVolumeClaim.ClaimName] {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,203 @@
+package tenantcleanup
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_cleanup(t *testing.T) {
+	testCases := []struct {
+		name            string
+		objects         []runtime.Object
+		expectRemaining int
+		expectServices  []string
+		expectClaims    []string
+		expectPods      []string
+	}{
+		{
+			name:            "case 0: nothing to clean up",
+			objects:         nil,
+			expectRemaining: 0,
+			expectServices:  nil,
+			expectClaims:    nil,
+			expectPods:      nil,
+		},
+		{
+			name: "case 1: load balancers and provisioned volume claims are deleted",
+			objects: []runtime.Object{
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "kube-system"},
+					Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+				},
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "kubernetes", Namespace: "default"},
+					Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+				},
+				&corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "data",
+						Namespace:   "monitoring",
+						Annotations: map[string]string{storageProvisionerAnnotation: "kubernetes.io/aws-ebs"},
+					},
+				},
+				&corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: "static", Namespace: "monitoring"},
+				},
+			},
+			expectRemaining: 0,
+			expectServices:  []string{"kubernetes"},
+			expectClaims:    []string{"static"},
+			expectPods:      nil,
+		},
+		{
+			name: "case 2: volume claims provisioned by older Kubernetes versions are deleted",
+			objects: []runtime.Object{
+				&corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "data",
+						Namespace:   "monitoring",
+						Annotations: map[string]string{betaStorageProvisionerAnnotation: "kubernetes.io/aws-ebs"},
+					},
+				},
+			},
+			expectRemaining: 0,
+			expectServices:  nil,
+			expectClaims:    nil,
+			expectPods:      nil,
+		},
+		{
+			name: "case 3: pods using protected volume claims being deleted are deleted",
+			objects: []runtime.Object{
+				&corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "data",
+						Namespace:         "monitoring",
+						Annotations:       map[string]string{storageProvisionerAnnotation: "kubernetes.io/aws-ebs"},
+						DeletionTimestamp: &metav1.Time{Time: time.Now()},
+						Finalizers:        []string{pvcProtectionFinalizer},
+					},
+				},
+				newPod("monitoring", "prometheus-0", "data"),
+				newPod("monitoring", "grafana", "dashboards"),
+				newPod("default", "other", "data"),
+			},
+			expectRemaining: 1,
+			expectServices:  nil,
+			expectClaims:    []string{"data"},
+			expectPods:      []string{"grafana", "other"},
+		},
+		{
+			name: "case 4: provisioned volumes are waited for",
+			objects: []runtime.Object{
+				&corev1.PersistentVolume{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "pvc-1",
+						Annotations: map[string]string{provisionedByAnnotation: "kubernetes.io/aws-ebs"},
+					},
+					Spec: corev1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete},
+				},
+				&corev1.PersistentVolume{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "pvc-2",
+						Annotations: map[string]string{provisionedByAnnotation: "kubernetes.io/aws-ebs"},
+					},
+					Spec: corev1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain},
+				},
+				&corev1.PersistentVolume{
+					ObjectMeta: metav1.ObjectMeta{Name: "static"},
+					Spec:       corev1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete},
+				},
+			},
+			expectRemaining: 1,
+			expectServices:  nil,
+			expectClaims:    nil,
+			expectPods:      nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			ctx := context.Background()
+			k8sClient := fake.NewSimpleClientset(tc.objects...)
+
+			remaining, err := cleanup(ctx, k8sClient)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The fake client deletes objects immediately, so only objects of
+			// which the deletion was requested already or which are deleted by
+			// the tenant cluster are left to wait for.
+			if remaining != tc.expectRemaining {
+				t.Fatalf("expected %d to be equal to %d", tc.expectRemaining, remaining)
+			}
+
+			services, err := k8sClient.CoreV1().Services(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(services.Items) != len(tc.expectServices) {
+				t.Fatalf("expected %d to be equal to %d", len(tc.expectServices), len(services.Items))
+			}
+			for j, s := range services.Items {
+				if s.Name != tc.expectServices[j] {
+					t.Fatalf("expected %#q to be equal to %#q", tc.expectServices[j], s.Name)
+				}
+			}
+
+			pvcs, err := k8sClient.CoreV1().PersistentVolumeClaims(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(pvcs.Items) != len(tc.expectClaims) {
+				t.Fatalf("expected %d to be equal to %d", len(tc.expectClaims), len(pvcs.Items))
+			}
+			for j, p := range pvcs.Items {
+				if p.Name != tc.expectClaims[j] {
+					t.Fatalf("expected %#q to be equal to %#q", tc.expectClaims[j], p.Name)
+				}
+			}
+
+			pods, err := k8sClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(pods.Items) != len(tc.expectPods) {
+				t.Fatalf("expected %d to be equal to %d", len(tc.expectPods), len(pods.Items))
+			}
+			for j, p := range pods.Items {
+				if p.Name != tc.expectPods[j] {
+					t.Fatalf("expected %#q to be equal to %#q", tc.expectPods[j], p.Name)
+				}
+			}
+		})
+	}
+}
+
+func newPod(namespace, name, claimName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{
+					Name: "data",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: claimName,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,86 @@
+package tenantcleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/giantswarm/errors/tenant"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/reconciliationcanceledcontext"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/tenantclient"
+)
+
+func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	tenantClient, err := r.tenantClient.K8sClient(ctx, &cr)
+	if tenantclient.IsNotAvailable(err) {
+		r.logger.Debugf(ctx, "tenant client not available")
+		r.logger.Debugf(ctx, "canceling resource")
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var remaining int
+	{
+		r.logger.Debugf(ctx, "deleting load balancer services, provisioned volume claims and volumes in tenant cluster")
+
+		remaining, err = cleanup(ctx, tenantClient.K8sClient())
+		if tenant.IsAPINotAvailable(err) {
+			// Nothing can be cleaned up without the tenant API, e.g. when the
+			// tenant cluster is broken or was partially deleted already.
+			r.logger.Debugf(ctx, "tenant API not available")
+			r.logger.Debugf(ctx, "canceling resource")
+			return nil
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		if remaining == 0 {
+			r.logger.Debugf(ctx, "deleted load balancer services, provisioned volume claims and volumes in tenant cluster")
+			return nil
+		}
+
+		r.logger.Debugf(ctx, "%d load balancer services, provisioned volume claims and volumes still exist in tenant cluster", remaining)
+	}
+
+	// The timeout counts from the start of the deletion phase the cleanup runs
+	// in. The phase is only missing from the cached Cluster CR during the
+	// reconciliation loop starting it. Once the deletion advanced past the
+	// phase, the cleanup finished or timed out already.
+	since := time.Now()
+	if p, ok := key.DeletionPhaseFromAnnotation(&cr); ok {
+		if p.Name != key.DeletionPhaseApps {
+			r.logger.Debugf(ctx, "not waiting in deletion phase %#q", p.Name)
+			return nil
+		}
+
+		since = p.Since.Time
+	}
+
+	if time.Since(since) > r.timeout {
+		// The deletion phase may not be completed right away, so we remember
+		// the timed out cleanup in order to only warn about it once.
+		k := fmt.Sprintf("%s/%d", cr.GetUID(), since.Unix())
+		if _, warned := r.warned.Get(k); !warned {
+			r.event.Warn(ctx, &cr, "TenantCleanupTimedOut", fmt.Sprintf("%d load balancer services, provisioned volume claims and volumes still exist after %s, their cloud resources may be orphaned", remaining, r.timeout))
+			r.warned.SetDefault(k, true)
+		}
+
+		r.logger.Debugf(ctx, "continuing deletion after timeout of %s", r.timeout)
+		return nil
+	}
+
+	r.logger.Debugf(ctx, "keeping finalizers")
+	r.logger.Debugf(ctx, "canceling reconciliation")
+	reconciliationcanceledcontext.SetCanceled(ctx)
+
+	return nil
+}
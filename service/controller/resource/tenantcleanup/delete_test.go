@@ -0,0 +1,152 @@
+package tenantcleanup
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/k8sclient/v5/pkg/k8sclienttest"
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/reconciliationcanceledcontext"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/tenantclient/unittest"
+)
+
+type eventRecorder struct {
+	warnings int
+}
+
+func (e *eventRecorder) Emit(ctx context.Context, obj pkgruntime.Object, reason, message string) {}
+
+func (e *eventRecorder) Warn(ctx context.Context, obj pkgruntime.Object, reason, message string) {
+	e.warnings++
+}
+
+func Test_Resource_EnsureDeleted(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name             string
+		deletedSince     time.Duration
+		phase            *key.DeletionPhase
+		expectCanceled   bool
+		expectedWarnings int
+	}{
+		{
+			name:             "case 0: cleanup within the timeout is waited for",
+			deletedSince:     5 * time.Minute,
+			phase:            &key.DeletionPhase{Name: key.DeletionPhaseApps, Since: metav1.NewTime(now.Add(-5 * time.Minute))},
+			expectCanceled:   true,
+			expectedWarnings: 0,
+		},
+		{
+			name:             "case 1: cleanup of a cluster protected from deletion for long is waited for",
+			deletedSince:     2 * time.Hour,
+			phase:            &key.DeletionPhase{Name: key.DeletionPhaseApps, Since: metav1.NewTime(now.Add(-time.Minute))},
+			expectCanceled:   true,
+			expectedWarnings: 0,
+		},
+		{
+			name:             "case 2: cleanup in the loop starting the deletion phase is waited for",
+			deletedSince:     2 * time.Hour,
+			phase:            nil,
+			expectCanceled:   true,
+			expectedWarnings: 0,
+		},
+		{
+			name:             "case 3: timed out cleanup is warned about once",
+			deletedSince:     time.Hour,
+			phase:            &key.DeletionPhase{Name: key.DeletionPhaseApps, Since: metav1.NewTime(now.Add(-20 * time.Minute))},
+			expectCanceled:   false,
+			expectedWarnings: 1,
+		},
+		{
+			name:             "case 4: cleanup is not waited for in later deletion phases",
+			deletedSince:     time.Hour,
+			phase:            &key.DeletionPhase{Name: key.DeletionPhaseNodePools, Since: metav1.NewTime(now.Add(-time.Minute))},
+			expectCanceled:   false,
+			expectedWarnings: 0,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var err error
+
+			// The provisioned volume is only deleted by the tenant cluster, so it
+			// remains to be waited for.
+			pv := &corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pvc-1",
+					Annotations: map[string]string{provisionedByAnnotation: "kubernetes.io/aws-ebs"},
+				},
+				Spec: corev1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete},
+			}
+
+			cl := &apiv1alpha2.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "a2wax",
+					Namespace:         "default",
+					DeletionTimestamp: &metav1.Time{Time: now.Add(-tc.deletedSince)},
+					UID:               "uid",
+				},
+			}
+			if tc.phase != nil {
+				cl.SetAnnotations(map[string]string{
+					annotation.DeletionPhase: key.DeletionPhaseAnnotation(*tc.phase),
+				})
+			}
+
+			e := &eventRecorder{}
+
+			var r *Resource
+			{
+				k8sClient := k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+					K8sClient: fake.NewSimpleClientset(pv),
+				})
+
+				c := Config{
+					Event:        e,
+					K8sClient:    k8sClient,
+					Logger:       microloggertest.New(),
+					TenantClient: unittest.FakeTenantClient(k8sClient),
+
+					Timeout: 10 * time.Minute,
+				}
+
+				r, err = New(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// The resource runs in two reconciliation loops, so that warnings
+			// emitted more than once are detected.
+			for j := 0; j < 2; j++ {
+				ctx := reconciliationcanceledcontext.NewContext(context.Background(), make(chan struct{}))
+
+				err = r.EnsureDeleted(ctx, cl)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				canceled := reconciliationcanceledcontext.IsCanceled(ctx)
+				if canceled != tc.expectCanceled {
+					t.Fatalf("expected %t to be equal to %t", tc.expectCanceled, canceled)
+				}
+			}
+
+			if e.warnings != tc.expectedWarnings {
+				t.Fatalf("expected %d to be equal to %d", tc.expectedWarnings, e.warnings)
+			}
+		})
+	}
+}
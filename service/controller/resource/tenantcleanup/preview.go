@@ -0,0 +1,61 @@
+package tenantcleanup
+
+import (
+	"context"
+
+	"github.com/giantswarm/errors/tenant"
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/deletionpreview"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/tenantclient"
+)
+
+func (r *Resource) PreviewDeleted(ctx context.Context, obj interface{}) ([]deletionpreview.Object, error) {
+	cr, err := key.ToCluster(obj)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	tenantClient, err := r.tenantClient.K8sClient(ctx, &cr)
+	if tenantclient.IsNotAvailable(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	services, err := loadBalancerServices(ctx, tenantClient.K8sClient())
+	if tenant.IsAPINotAvailable(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	pvcs, err := provisionedPersistentVolumeClaims(ctx, tenantClient.K8sClient())
+	if tenant.IsAPINotAvailable(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var objects []deletionpreview.Object
+	{
+		o, err := deletionpreview.ObjectsOf(services)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		objects = append(objects, o...)
+
+		o, err = deletionpreview.ObjectsOf(pvcs)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		objects = append(objects, o...)
+	}
+
+	for i := range objects {
+		objects[i].Tenant = true
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,92 @@
+package tenantcleanup
+
+import (
+	"time"
+
+	"github.com/giantswarm/k8sclient/v5/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	gocache "github.com/patrickmn/go-cache"
+
+	"github.com/giantswarm/cluster-operator/v3/service/internal/recorder"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/tenantclient"
+)
+
+const (
+	Name = "tenantcleanup"
+)
+
+const (
+	// warnedExpiration is the duration timed out cleanups are remembered in
+	// order to only warn about them once. It only has to outlive the deletion
+	// phase the cleanup runs in.
+	warnedExpiration = 24 * time.Hour
+)
+
+type Config struct {
+	Event        recorder.Interface
+	K8sClient    k8sclient.Interface
+	Logger       micrologger.Logger
+	TenantClient tenantclient.Interface
+
+	// Timeout is the duration after the start of the Apps deletion phase after
+	// which the deletion continues, even if cloud resources of the tenant
+	// cluster are still being deleted. The deletion timestamp of the Cluster CR
+	// is not used, because the deletion may have been blocked by the deletion
+	// protection for longer than that.
+	Timeout time.Duration
+}
+
+// Resource deletes the LoadBalancer Services and dynamically provisioned
+// PersistentVolumeClaims of a tenant cluster before its infrastructure is
+// deleted, so that the load balancers and volumes backing them are not
+// orphaned. Pods using the claims are deleted, as the claims are only deleted
+// once no Pod uses them. It cancels the reconciliation until the claims and
+// their PersistentVolumes are gone or the timeout is reached, which keeps the
+// finalizers and skips all resources deleting the infrastructure of the
+// tenant cluster.
+type Resource struct {
+	event        recorder.Interface
+	k8sClient    k8sclient.Interface
+	logger       micrologger.Logger
+	tenantClient tenantclient.Interface
+
+	timeout time.Duration
+	// warned holds the timed out cleanups a Warning event was emitted for.
+	warned *gocache.Cache
+}
+
+func New(config Config) (*Resource, error) {
+	if config.Event == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Event must not be empty", config)
+	}
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.TenantClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.TenantClient must not be empty", config)
+	}
+
+	if config.Timeout == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Timeout must not be empty", config)
+	}
+
+	r := &Resource{
+		event:        config.Event,
+		k8sClient:    config.K8sClient,
+		logger:       config.Logger,
+		tenantClient: config.TenantClient,
+
+		timeout: config.Timeout,
+		warned:  gocache.New(warnedExpiration, warnedExpiration/2),
+	}
+
+	return r, nil
+}
+
+func (r *Resource) Name() string {
+	return Name
+}
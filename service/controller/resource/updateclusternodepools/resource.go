@@ -0,0 +1,141 @@
+package updateclusternodepools
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/giantswarm/k8sclient/v5/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+const (
+	Name = "updateclusternodepools"
+)
+
+type Config struct {
+	K8sClient k8sclient.Interface
+	Logger    micrologger.Logger
+}
+
+// Resource implements the operatorkit resource interface to propagate the
+// number of node pools of a Tenant Cluster from its MachineDeployment CRs to
+// the following annotation of its Cluster CR.
+//
+//     cluster-operator.giantswarm.io/node-pools
+//
+// The Cluster CR is not reconciled when MachineDeployment CRs change. Updating
+// the annotation ensures the cluster values of the Tenant Cluster reflect its
+// node pools right away.
+type Resource struct {
+	k8sClient k8sclient.Interface
+	logger    micrologger.Logger
+}
+
+func New(config Config) (*Resource, error) {
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	r := &Resource{
+		k8sClient: config.K8sClient,
+		logger:    config.Logger,
+	}
+
+	return r, nil
+}
+
+func (r *Resource) Name() string {
+	return Name
+}
+
+func (r *Resource) ensure(ctx context.Context, obj interface{}) error {
+	md, err := key.ToMachineDeployment(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var cl apiv1alpha2.Cluster
+	{
+		r.logger.Debugf(ctx, "finding Cluster CR for tenant cluster %#q", key.ClusterID(&md))
+
+		var list apiv1alpha2.ClusterList
+		err = r.k8sClient.CtrlClient().List(
+			ctx,
+			&list,
+			client.InNamespace(md.Namespace),
+			client.MatchingLabels{label.Cluster: key.ClusterID(&md)},
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		if len(list.Items) == 0 {
+			r.logger.Debugf(ctx, "did not find Cluster CR for tenant cluster %#q", key.ClusterID(&md))
+			r.logger.Debugf(ctx, "canceling resource")
+			return nil
+		}
+
+		cl = list.Items[0]
+
+		r.logger.Debugf(ctx, "found Cluster CR for tenant cluster %#q", key.ClusterID(&md))
+	}
+
+	if key.IsDeleted(&cl) {
+		r.logger.Debugf(ctx, "Cluster CR for tenant cluster %#q is being deleted", key.ClusterID(&md))
+		r.logger.Debugf(ctx, "canceling resource")
+		return nil
+	}
+
+	var nodePools string
+	{
+		mdList := &apiv1alpha2.MachineDeploymentList{}
+		err = r.k8sClient.CtrlClient().List(
+			ctx,
+			mdList,
+			client.InNamespace(md.Namespace),
+			client.MatchingLabels{label.Cluster: key.ClusterID(&md)},
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		nodePools = strconv.Itoa(key.NodePoolCount(mdList.Items))
+	}
+
+	if cl.GetAnnotations()[annotation.NodePools] == nodePools {
+		r.logger.Debugf(ctx, "annotation %#q of Cluster CR is up to date", annotation.NodePools)
+		return nil
+	}
+
+	{
+		r.logger.Debugf(ctx, "updating annotation %#q of Cluster CR to %#q", annotation.NodePools, nodePools)
+
+		patch := client.MergeFrom(cl.DeepCopy())
+
+		a := cl.GetAnnotations()
+		if a == nil {
+			a = map[string]string{}
+		}
+		a[annotation.NodePools] = nodePools
+		cl.SetAnnotations(a)
+
+		err = r.k8sClient.CtrlClient().Patch(ctx, &cl, patch)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.Debugf(ctx, "updated annotation %#q of Cluster CR to %#q", annotation.NodePools, nodePools)
+	}
+
+	return nil
+}
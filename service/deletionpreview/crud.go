@@ -0,0 +1,137 @@
+package deletionpreview
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/reconciliationcanceledcontext"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/resourcecanceledcontext"
+	"github.com/giantswarm/operatorkit/v4/pkg/resource/crud"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// PreviewCRUD runs the EnsureDeleted logic of the given CRUD resource and
+// returns the objects of its delete change instead of applying any change.
+// Resources canceling themselves or the reconciliation stop the preview the
+// same way they stop the deletion, so that no further state is computed.
+func PreviewCRUD(ctx context.Context, logger micrologger.Logger, ops crud.Interface, obj interface{}) ([]Object, error) {
+	ctx = reconciliationcanceledcontext.NewContext(ctx, make(chan struct{}))
+	ctx = resourcecanceledcontext.NewContext(ctx, make(chan struct{}))
+
+	rec := &recorder{
+		Interface: ops,
+	}
+
+	var r *crud.Resource
+	{
+		c := crud.ResourceConfig{
+			CRUD:   rec,
+			Logger: logger,
+		}
+
+		var err error
+		r, err = crud.NewResource(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	err := r.EnsureDeleted(ctx, obj)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return rec.objects, nil
+}
+
+// ObjectsOf returns the objects contained in the given delete change, which is
+// either a single object or a slice of objects.
+func ObjectsOf(v interface{}) ([]Object, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return nil, nil
+	}
+
+	if rv.Kind() != reflect.Slice {
+		o, ok, err := objectOf(rv)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		} else if !ok {
+			return nil, nil
+		}
+
+		return []Object{o}, nil
+	}
+
+	var objects []Object
+	for i := 0; i < rv.Len(); i++ {
+		o, ok, err := objectOf(rv.Index(i))
+		if err != nil {
+			return nil, microerror.Mask(err)
+		} else if !ok {
+			continue
+		}
+
+		objects = append(objects, o)
+	}
+
+	return objects, nil
+}
+
+func objectOf(rv reflect.Value) (Object, bool, error) {
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return Object{}, false, nil
+	}
+
+	m, err := meta.Accessor(rv.Interface())
+	if err != nil {
+		return Object{}, false, microerror.Mask(err)
+	}
+
+	// Typed objects usually come without kind, in which case it is derived
+	// from their Go type.
+	var kind string
+	if t, err := meta.TypeAccessor(rv.Interface()); err == nil {
+		kind = t.GetKind()
+	}
+	if kind == "" {
+		kind = reflect.Indirect(rv).Type().Name()
+	}
+
+	o := Object{
+		Kind:      kind,
+		Name:      m.GetName(),
+		Namespace: m.GetNamespace(),
+	}
+
+	return o, true, nil
+}
+
+// recorder is a CRUD resource recording the objects of delete changes
+// instead of applying any change.
+type recorder struct {
+	crud.Interface
+
+	objects []Object
+}
+
+func (r *recorder) ApplyCreateChange(ctx context.Context, obj, createChange interface{}) error {
+	return nil
+}
+
+func (r *recorder) ApplyDeleteChange(ctx context.Context, obj, deleteChange interface{}) error {
+	objects, err := ObjectsOf(deleteChange)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.objects = append(r.objects, objects...)
+
+	return nil
+}
+
+func (r *recorder) ApplyUpdateChange(ctx context.Context, obj, updateChange interface{}) error {
+	return nil
+}
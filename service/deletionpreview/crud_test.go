@@ -0,0 +1,137 @@
+package deletionpreview
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/resourcecanceledcontext"
+	"github.com/giantswarm/operatorkit/v4/pkg/resource/crud"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// fakeCRUD returns its current state as delete change and fails when any
+// change is applied. When canceling, it cancels the resource after computing
+// the current state and fails when the desired state is computed.
+type fakeCRUD struct {
+	cancel  bool
+	current interface{}
+	t       *testing.T
+}
+
+func (f *fakeCRUD) Name() string { return "fake" }
+
+func (f *fakeCRUD) GetCurrentState(ctx context.Context, obj interface{}) (interface{}, error) {
+	if f.cancel {
+		resourcecanceledcontext.SetCanceled(ctx)
+	}
+	return f.current, nil
+}
+
+func (f *fakeCRUD) GetDesiredState(ctx context.Context, obj interface{}) (interface{}, error) {
+	if f.cancel {
+		f.t.Fatal("desired state computed")
+	}
+	return nil, nil
+}
+
+func (f *fakeCRUD) NewUpdatePatch(ctx context.Context, obj, currentState, desiredState interface{}) (*crud.Patch, error) {
+	return nil, nil
+}
+
+func (f *fakeCRUD) NewDeletePatch(ctx context.Context, obj, currentState, desiredState interface{}) (*crud.Patch, error) {
+	p := crud.NewPatch()
+	p.SetCreateChange(currentState)
+	p.SetDeleteChange(currentState)
+	return p, nil
+}
+
+func (f *fakeCRUD) ApplyCreateChange(ctx context.Context, obj, createChange interface{}) error {
+	f.t.Fatal("create change applied")
+	return nil
+}
+
+func (f *fakeCRUD) ApplyDeleteChange(ctx context.Context, obj, deleteChange interface{}) error {
+	f.t.Fatal("delete change applied")
+	return nil
+}
+
+func (f *fakeCRUD) ApplyUpdateChange(ctx context.Context, obj, updateChange interface{}) error {
+	f.t.Fatal("update change applied")
+	return nil
+}
+
+func Test_PreviewCRUD(t *testing.T) {
+	infraRef := &unstructured.Unstructured{}
+	infraRef.SetKind("AWSCluster")
+	infraRef.SetName("al9qy")
+	infraRef.SetNamespace("default")
+
+	testCases := []struct {
+		name            string
+		cancel          bool
+		current         interface{}
+		expectedObjects []Object
+	}{
+		{
+			name:            "case 0: nothing to delete",
+			current:         nil,
+			expectedObjects: nil,
+		},
+		{
+			name:            "case 1: nil pointer is ignored",
+			current:         (*corev1.Namespace)(nil),
+			expectedObjects: nil,
+		},
+		{
+			name:    "case 2: single typed object",
+			current: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "al9qy"}},
+			expectedObjects: []Object{
+				{Kind: "Namespace", Name: "al9qy"},
+			},
+		},
+		{
+			name: "case 3: slice of typed objects",
+			current: []*corev1.Secret{
+				{ObjectMeta: metav1.ObjectMeta{Name: "al9qy-kubeconfig", Namespace: "al9qy"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "al9qy-encryption", Namespace: "default"}},
+			},
+			expectedObjects: []Object{
+				{Kind: "Secret", Name: "al9qy-kubeconfig", Namespace: "al9qy"},
+				{Kind: "Secret", Name: "al9qy-encryption", Namespace: "default"},
+			},
+		},
+		{
+			name:    "case 4: unstructured object",
+			current: infraRef,
+			expectedObjects: []Object{
+				{Kind: "AWSCluster", Name: "al9qy", Namespace: "default"},
+			},
+		},
+		{
+			name:            "case 5: canceled resource is not previewed any further",
+			cancel:          true,
+			current:         &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "al9qy"}},
+			expectedObjects: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			ops := &fakeCRUD{cancel: tc.cancel, current: tc.current, t: t}
+
+			objects, err := PreviewCRUD(context.Background(), microloggertest.New(), ops, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(objects, tc.expectedObjects) {
+				t.Fatalf("expected %#v to be equal to %#v", tc.expectedObjects, objects)
+			}
+		})
+	}
+}
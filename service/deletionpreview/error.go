@@ -0,0 +1,29 @@
+package deletionpreview
+
+import (
+	"github.com/giantswarm/microerror"
+)
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var notFoundError = &microerror.Error{
+	Kind: "notFoundError",
+}
+
+// IsNotFound asserts notFoundError.
+func IsNotFound(err error) bool {
+	return microerror.Cause(err) == notFoundError
+}
+
+// NotFoundError returns an error matching IsNotFound, so that implementations
+// of Interface in other packages can report unknown tenant clusters.
+func NotFoundError(clusterID string) error {
+	return microerror.Maskf(notFoundError, "cluster %#q", clusterID)
+}
@@ -0,0 +1,23 @@
+package deletionpreview
+
+import "context"
+
+// Interface previews the deletion of tenant clusters.
+type Interface interface {
+	// Preview returns the objects the deletion of the given tenant cluster
+	// would delete, in the order they would be deleted, without changing
+	// anything. An error matching IsNotFound is returned when the tenant
+	// cluster does not exist.
+	Preview(ctx context.Context, clusterID string) (Preview, error)
+}
+
+// Previewer is implemented by resources deleting objects in EnsureDeleted
+// which are not CRUD resources. CRUD resources are previewed by recording
+// their delete changes instead of applying them, unless their CRUD
+// implementation implements Previewer, e.g. because computing their state is
+// not free of side effects.
+type Previewer interface {
+	// PreviewDeleted returns the objects EnsureDeleted would delete for the
+	// given object without deleting them.
+	PreviewDeleted(ctx context.Context, obj interface{}) ([]Object, error)
+}
@@ -0,0 +1,25 @@
+package deletionpreview
+
+// Object is an object deleted during the deletion of a tenant cluster.
+type Object struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// Phase is the deletion phase the object is deleted in.
+	Phase string `json:"phase,omitempty"`
+	// Resource is the name of the resource deleting the object.
+	Resource string `json:"resource"`
+	// Tenant is true for objects living in the tenant cluster rather than in
+	// the management cluster.
+	Tenant bool `json:"tenant,omitempty"`
+}
+
+// Preview is the result of previewing the deletion of a tenant cluster.
+type Preview struct {
+	ClusterID string `json:"cluster_id"`
+	// DeletionProtected is true when the tenant cluster is protected from
+	// deletion, in which case none of the objects would be deleted until the
+	// protection is removed.
+	DeletionProtected bool     `json:"deletion_protected"`
+	Objects           []Object `json:"objects"`
+}
@@ -0,0 +1,162 @@
+package clusterip
+
+import (
+	"context"
+
+	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
+	"github.com/giantswarm/k8sclient/v5/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/clusterip/internal/cache"
+)
+
+type Config struct {
+	K8sClient k8sclient.Interface
+
+	InstallationClusterIPRange string
+}
+
+type ClusterIP struct {
+	k8sClient k8sclient.Interface
+
+	clusterIPRangeCache *cache.ClusterIPRange
+
+	installationClusterIPRange string
+}
+
+func New(c Config) (*ClusterIP, error) {
+	if c.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", c)
+	}
+
+	if c.InstallationClusterIPRange == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.InstallationClusterIPRange must not be empty", c)
+	}
+
+	r, _, err := key.ParseClusterIPRanges(c.InstallationClusterIPRange)
+	if err != nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.InstallationClusterIPRange must be valid: %s", c, err)
+	}
+
+	ci := &ClusterIP{
+		k8sClient: c.K8sClient,
+
+		clusterIPRangeCache: cache.NewClusterIPRange(),
+
+		installationClusterIPRange: r,
+	}
+
+	return ci, nil
+}
+
+func (ci *ClusterIP) APIIPs(ctx context.Context, obj interface{}) ([]string, error) {
+	r, err := ci.ClusterIPRange(ctx, obj)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	_, apiIPs, err := key.ParseClusterIPRanges(r)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return apiIPs, nil
+}
+
+func (ci *ClusterIP) ClusterIPRange(ctx context.Context, obj interface{}) (string, error) {
+	cr, err := meta.Accessor(obj)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	r, err := ci.cachedClusterIPRange(ctx, cr)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return r, nil
+}
+
+func (ci *ClusterIP) DNSIPs(ctx context.Context, obj interface{}) ([]string, error) {
+	r, err := ci.ClusterIPRange(ctx, obj)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	dnsIPs, err := key.DNSIPs(r)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return dnsIPs, nil
+}
+
+func (ci *ClusterIP) cachedClusterIPRange(ctx context.Context, cr metav1.Object) (string, error) {
+	var err error
+	var ok bool
+
+	var r string
+	{
+		ck := ci.clusterIPRangeCache.Key(ctx, cr)
+
+		if ck == "" {
+			r, err = ci.lookupClusterIPRange(ctx, cr)
+			if err != nil {
+				return "", microerror.Mask(err)
+			}
+		} else {
+			r, ok = ci.clusterIPRangeCache.Get(ctx, ck)
+			if !ok {
+				r, err = ci.lookupClusterIPRange(ctx, cr)
+				if err != nil {
+					return "", microerror.Mask(err)
+				}
+
+				ci.clusterIPRangeCache.Set(ctx, ck, r)
+			}
+		}
+	}
+
+	return r, nil
+}
+
+func (ci *ClusterIP) lookupClusterIPRange(ctx context.Context, cr metav1.Object) (string, error) {
+	var list infrastructurev1alpha2.AWSClusterList
+
+	err := ci.k8sClient.CtrlClient().List(
+		ctx,
+		&list,
+		client.InNamespace(cr.GetNamespace()),
+		client.MatchingLabels{label.Cluster: key.ClusterID(cr)},
+	)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	if len(list.Items) == 0 {
+		return "", microerror.Mask(notFoundError)
+	}
+	if len(list.Items) > 1 {
+		return "", microerror.Mask(tooManyCRsError)
+	}
+
+	v := list.Items[0].GetAnnotations()[annotation.ClusterIPRange]
+	if v == "" {
+		return ci.installationClusterIPRange, nil
+	}
+
+	// The cluster specific range is subject to the same validation as the
+	// installation default, most notably ranges smaller than /24 are rejected.
+	r, _, err := key.ParseClusterIPRanges(v)
+	if err != nil {
+		return "", microerror.Maskf(invalidConfigError, "annotation %#q of AWSCluster CR %#q must be valid: %s", annotation.ClusterIPRange, list.Items[0].GetName(), err)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,130 @@
+package clusterip
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+
+	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/unittest"
+)
+
+func Test_ClusterIP_Lookup(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		annotation             string
+		expectedClusterIPRange string
+		expectedAPIIPs         []string
+		expectedDNSIPs         []string
+		errorMatcher           func(error) bool
+	}{
+		{
+			name:                   "case 0: installation fallback",
+			annotation:             "",
+			expectedClusterIPRange: "172.31.0.0/16",
+			expectedAPIIPs:         []string{"172.31.0.1"},
+			expectedDNSIPs:         []string{"172.31.0.10"},
+			errorMatcher:           nil,
+		},
+		{
+			name:                   "case 1: cluster specific IPv4 range",
+			annotation:             "10.100.0.0/24",
+			expectedClusterIPRange: "10.100.0.0/24",
+			expectedAPIIPs:         []string{"10.100.0.1"},
+			expectedDNSIPs:         []string{"10.100.0.10"},
+			errorMatcher:           nil,
+		},
+		{
+			name:                   "case 2: cluster specific dual-stack range",
+			annotation:             "10.100.0.0/16, fd00:10:96::/112",
+			expectedClusterIPRange: "10.100.0.0/16,fd00:10:96::/112",
+			expectedAPIIPs:         []string{"10.100.0.1", "fd00:10:96::1"},
+			expectedDNSIPs:         []string{"10.100.0.10", "fd00:10:96::a"},
+			errorMatcher:           nil,
+		},
+		{
+			name:                   "case 3: cluster specific range smaller than /24",
+			annotation:             "10.100.0.0/25",
+			expectedClusterIPRange: "",
+			expectedAPIIPs:         nil,
+			expectedDNSIPs:         nil,
+			errorMatcher:           IsInvalidConfig,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var err error
+			ctx := context.Background()
+
+			var ci *ClusterIP
+			{
+				c := Config{
+					K8sClient: unittest.FakeK8sClient(),
+
+					InstallationClusterIPRange: "172.31.0.0/16",
+				}
+
+				ci, err = New(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var cl infrastructurev1alpha2.AWSCluster
+			{
+				cl = unittest.DefaultCluster()
+				if tc.annotation != "" {
+					cl.Annotations = map[string]string{
+						annotation.ClusterIPRange: tc.annotation,
+					}
+				}
+
+				err = ci.k8sClient.CtrlClient().Create(ctx, &cl)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			clusterIPRange, err := ci.ClusterIPRange(ctx, &cl)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if clusterIPRange != tc.expectedClusterIPRange {
+				t.Fatalf("expected %#q to be equal to %#q", tc.expectedClusterIPRange, clusterIPRange)
+			}
+
+			if tc.errorMatcher != nil {
+				return
+			}
+
+			apiIPs, err := ci.APIIPs(ctx, &cl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(apiIPs, tc.expectedAPIIPs) {
+				t.Fatalf("expected %#v to be equal to %#v", tc.expectedAPIIPs, apiIPs)
+			}
+
+			dnsIPs, err := ci.DNSIPs(ctx, &cl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(dnsIPs, tc.expectedDNSIPs) {
+				t.Fatalf("expected %#v to be equal to %#v", tc.expectedDNSIPs, dnsIPs)
+			}
+		})
+	}
+}
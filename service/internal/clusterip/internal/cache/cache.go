@@ -0,0 +1,7 @@
+package cache
+
+import "time"
+
+const (
+	expiration = 5 * time.Minute
+)
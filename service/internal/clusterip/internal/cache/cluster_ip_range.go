@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/cachekeycontext"
+	gocache "github.com/patrickmn/go-cache"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+type ClusterIPRange struct {
+	cache *gocache.Cache
+}
+
+func NewClusterIPRange() *ClusterIPRange {
+	r := &ClusterIPRange{
+		cache: gocache.New(expiration, expiration/2),
+	}
+
+	return r
+}
+
+func (r *ClusterIPRange) Get(ctx context.Context, key string) (string, bool) {
+	val, ok := r.cache.Get(key)
+	if ok {
+		return val.(string), true
+	}
+
+	return "", false
+}
+
+func (r *ClusterIPRange) Key(ctx context.Context, obj metav1.Object) string {
+	ck, ok := cachekeycontext.FromContext(ctx)
+	if ok {
+		return fmt.Sprintf("%s/%s", ck, key.ClusterID(obj))
+	}
+
+	return ""
+}
+
+func (r *ClusterIPRange) Set(ctx context.Context, key string, val string) {
+	r.cache.SetDefault(key, val)
+}
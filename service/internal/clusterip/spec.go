@@ -0,0 +1,19 @@
+package clusterip
+
+import (
+	"context"
+)
+
+type Interface interface {
+	// APIIPs returns the API server service IPs of the Tenant Cluster, one per
+	// IP family of its cluster IP range.
+	APIIPs(ctx context.Context, obj interface{}) ([]string, error)
+	// ClusterIPRange provides the comma separated service CIDRs to be used for
+	// Tenant Clusters depending on the installation and AWSCluster CR
+	// configuration. The CR value is prefered over the default value in the
+	// installation.
+	ClusterIPRange(ctx context.Context, obj interface{}) (string, error)
+	// DNSIPs returns the DNS service IPs of the Tenant Cluster, one per IP
+	// family of its cluster IP range.
+	DNSIPs(ctx context.Context, obj interface{}) ([]string, error)
+}
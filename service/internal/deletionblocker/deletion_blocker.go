@@ -0,0 +1,232 @@
+package deletionblocker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/giantswarm/k8sclient/v5/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/recorder"
+)
+
+type Config struct {
+	Event     recorder.Interface
+	K8sClient k8sclient.Interface
+
+	// MaxAge is the duration after which objects blocking the deletion of a
+	// tenant cluster are reported using Warning events.
+	MaxAge time.Duration
+}
+
+type DeletionBlocker struct {
+	event     recorder.Interface
+	k8sClient k8sclient.Interface
+
+	maxAge time.Duration
+
+	mutex sync.Mutex
+	// warned holds the identities of the blockers Warning events were emitted
+	// for already, so that every blocker is only warned about once per
+	// operator process.
+	warned map[string]struct{}
+}
+
+func New(c Config) (*DeletionBlocker, error) {
+	if c.Event == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Event must not be empty", c)
+	}
+	if c.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", c)
+	}
+
+	if c.MaxAge == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.MaxAge must not be empty", c)
+	}
+
+	d := &DeletionBlocker{
+		event:     c.Event,
+		k8sClient: c.K8sClient,
+
+		maxAge: c.MaxAge,
+
+		warned: map[string]struct{}{},
+	}
+
+	return d, nil
+}
+
+func (d *DeletionBlocker) Report(ctx context.Context, obj interface{}, kind string, objs []metav1.Object) error {
+	cr, ok := obj.(*apiv1alpha2.Cluster)
+	if !ok {
+		return nil
+	}
+
+	// Multiple resources report their blockers during the same reconciliation
+	// loop, so we must not patch based on the cached object.
+	var cl apiv1alpha2.Cluster
+	{
+		err := d.k8sClient.CtrlClient().Get(ctx, types.NamespacedName{Name: cr.GetName(), Namespace: cr.GetNamespace()}, &cl)
+		if apierrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	now := time.Now()
+
+	current := key.DeletionBlockers(&cl)
+
+	var blockers []key.DeletionBlocker
+	for _, o := range objs {
+		blockers = append(blockers, newBlocker(now, current, kind, o))
+	}
+
+	desired := withBlockers(current, kind, blockers)
+
+	// Timestamps lose their location when being read from the annotation, so
+	// we compare the annotation values.
+	if key.DeletionBlockersAnnotation(current) != key.DeletionBlockersAnnotation(desired) {
+		patch := client.MergeFrom(cl.DeepCopy())
+
+		a := cl.GetAnnotations()
+		if a == nil {
+			a = map[string]string{}
+		}
+		if len(desired) == 0 {
+			delete(a, annotation.DeletionBlockers)
+		} else {
+			a[annotation.DeletionBlockers] = key.DeletionBlockersAnnotation(desired)
+		}
+		cl.SetAnnotations(a)
+
+		err := d.k8sClient.CtrlClient().Patch(ctx, &cl, patch)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	for _, b := range d.unwarned(&cl, kind, blockers, exceeded(blockers, now, d.maxAge)) {
+		d.event.Warn(ctx, &cl, "DeletionBlocked", fmt.Sprintf("%s %#q blocks the deletion of the cluster for %s", b.Kind, b.Name, key.DeletionBlockerAge(b, now)))
+	}
+
+	return nil
+}
+
+// unwarned returns the given exceeded blockers no Warning event was emitted for
+// yet and remembers them as warned. Blockers of the given kind which do not
+// block the deletion anymore are forgotten.
+func (d *DeletionBlocker) unwarned(cl *apiv1alpha2.Cluster, kind string, blockers []key.DeletionBlocker, exceeded []key.DeletionBlocker) []key.DeletionBlocker {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	prefix := fmt.Sprintf("%s/%s/%s/", cl.GetNamespace(), cl.GetName(), kind)
+
+	blocking := map[string]struct{}{}
+	for _, b := range blockers {
+		blocking[prefix+blockerID(b)] = struct{}{}
+	}
+	for id := range d.warned {
+		if _, ok := blocking[id]; strings.HasPrefix(id, prefix) && !ok {
+			delete(d.warned, id)
+		}
+	}
+
+	var result []key.DeletionBlocker
+	for _, b := range exceeded {
+		id := prefix + blockerID(b)
+		if _, ok := d.warned[id]; ok {
+			continue
+		}
+
+		d.warned[id] = struct{}{}
+		result = append(result, b)
+	}
+
+	return result
+}
+
+// blockerID returns the identity of the given blocker. The time the blocker
+// started blocking is part of it, so that recreated objects are warned about
+// again.
+func blockerID(b key.DeletionBlocker) string {
+	return fmt.Sprintf("%s/%s/%d", b.Namespace, b.Name, b.Since.Unix())
+}
+
+// exceeded returns the given blockers which have been blocking the deletion
+// for longer than the given age at the given time.
+func exceeded(blockers []key.DeletionBlocker, now time.Time, maxAge time.Duration) []key.DeletionBlocker {
+	var result []key.DeletionBlocker
+	for _, b := range blockers {
+		if b.Since.Time.Before(now.Add(-maxAge)) {
+			result = append(result, b)
+		}
+	}
+
+	return result
+}
+
+// newBlocker returns the deletion blocker for the given object. Objects being
+// deleted block the deletion since their own deletion timestamp. Other objects
+// block it since they were reported first, which is taken from the given
+// previously reported blockers. The deletion timestamp of the Cluster CR is
+// not used, because the deletion may have been blocked by the deletion
+// protection for a long time.
+func newBlocker(now time.Time, previous []key.DeletionBlocker, kind string, o metav1.Object) key.DeletionBlocker {
+	b := key.DeletionBlocker{
+		Kind:      kind,
+		Name:      o.GetName(),
+		Namespace: o.GetNamespace(),
+		Since:     metav1.NewTime(now.UTC().Truncate(time.Second)),
+	}
+
+	if o.GetDeletionTimestamp() != nil {
+		b.Since = metav1.NewTime(o.GetDeletionTimestamp().UTC().Truncate(time.Second))
+		return b
+	}
+
+	for _, p := range previous {
+		if p.Kind == b.Kind && p.Namespace == b.Namespace && p.Name == b.Name {
+			b.Since = p.Since
+			break
+		}
+	}
+
+	return b
+}
+
+// withBlockers returns the given current blockers with all blockers of the
+// given kind replaced by the given blockers, sorted by kind and name.
+func withBlockers(current []key.DeletionBlocker, kind string, blockers []key.DeletionBlocker) []key.DeletionBlocker {
+	var result []key.DeletionBlocker
+	for _, b := range current {
+		if b.Kind != kind {
+			result = append(result, b)
+		}
+	}
+	result = append(result, blockers...)
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
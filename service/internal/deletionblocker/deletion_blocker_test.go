@@ -0,0 +1,155 @@
+package deletionblocker
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+func Test_DeletionBlocker_withBlockers(t *testing.T) {
+	now := time.Date(2020, 12, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		current        []key.DeletionBlocker
+		kind           string
+		objs           []metav1.Object
+		expectBlockers []string
+		expectExceeded []string
+	}{
+		{
+			name:           "case 0: nothing blocks",
+			kind:           "MachineDeployment",
+			expectBlockers: nil,
+			expectExceeded: nil,
+		},
+		{
+			name: "case 1: blockers of other kinds are kept",
+			current: []key.DeletionBlocker{
+				{Kind: "G8sControlPlane", Name: "cp", Since: metav1.NewTime(now.Add(-90 * time.Minute))},
+				{Kind: "MachineDeployment", Name: "gone", Since: metav1.NewTime(now.Add(-90 * time.Minute))},
+			},
+			kind: "MachineDeployment",
+			objs: []metav1.Object{
+				&metav1.ObjectMeta{Name: "np2"},
+				&metav1.ObjectMeta{Name: "np1", DeletionTimestamp: &metav1.Time{Time: now.Add(-10 * time.Minute)}},
+			},
+			expectBlockers: []string{"G8sControlPlane/cp/1h30m0s", "MachineDeployment/np1/10m0s", "MachineDeployment/np2/0s"},
+			expectExceeded: nil,
+		},
+		{
+			name: "case 2: blockers keep the time they started blocking",
+			current: []key.DeletionBlocker{
+				{Kind: "MachineDeployment", Name: "np2", Since: metav1.NewTime(now.Add(-90 * time.Minute))},
+			},
+			kind: "MachineDeployment",
+			objs: []metav1.Object{
+				&metav1.ObjectMeta{Name: "np2"},
+			},
+			expectBlockers: []string{"MachineDeployment/np2/1h30m0s"},
+			expectExceeded: []string{"MachineDeployment/np2/1h30m0s"},
+		},
+		{
+			name: "case 3: blockers being deleted block since their deletion timestamp",
+			current: []key.DeletionBlocker{
+				{Kind: "MachineDeployment", Name: "np2", Since: metav1.NewTime(now.Add(-90 * time.Minute))},
+			},
+			kind: "MachineDeployment",
+			objs: []metav1.Object{
+				&metav1.ObjectMeta{Name: "np2", DeletionTimestamp: &metav1.Time{Time: now.Add(-10 * time.Minute)}},
+			},
+			expectBlockers: []string{"MachineDeployment/np2/10m0s"},
+			expectExceeded: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var blockers []key.DeletionBlocker
+			for _, o := range tc.objs {
+				blockers = append(blockers, newBlocker(now, tc.current, tc.kind, o))
+			}
+
+			result := withBlockers(tc.current, tc.kind, blockers)
+			if !reflect.DeepEqual(blockerIDs(result, now), tc.expectBlockers) {
+				t.Fatalf("expected %#v to be equal to %#v", tc.expectBlockers, blockerIDs(result, now))
+			}
+
+			e := exceeded(blockers, now, time.Hour)
+			if !reflect.DeepEqual(blockerIDs(e, now), tc.expectExceeded) {
+				t.Fatalf("expected %#v to be equal to %#v", tc.expectExceeded, blockerIDs(e, now))
+			}
+
+			if key.DeletionBlockersAnnotation(result) != key.DeletionBlockersAnnotation(withBlockers(result, tc.kind, blockers)) {
+				t.Fatalf("expected annotation to be stable across reports")
+			}
+		})
+	}
+}
+
+func Test_DeletionBlocker_unwarned(t *testing.T) {
+	now := time.Date(2020, 12, 1, 12, 0, 0, 0, time.UTC)
+
+	np1 := key.DeletionBlocker{Kind: "MachineDeployment", Name: "np1", Since: metav1.NewTime(now.Add(-90 * time.Minute))}
+	np2 := key.DeletionBlocker{Kind: "MachineDeployment", Name: "np2", Since: metav1.NewTime(now.Add(-90 * time.Minute))}
+	recreated := key.DeletionBlocker{Kind: "MachineDeployment", Name: "np1", Since: metav1.NewTime(now.Add(-61 * time.Minute))}
+
+	testCases := []struct {
+		name           string
+		blockers       []key.DeletionBlocker
+		expectUnwarned []string
+	}{
+		{
+			name:           "case 0: exceeded blockers are warned about",
+			blockers:       []key.DeletionBlocker{np1, np2},
+			expectUnwarned: []string{"MachineDeployment/np1/1h30m0s", "MachineDeployment/np2/1h30m0s"},
+		},
+		{
+			name:           "case 1: exceeded blockers are only warned about once",
+			blockers:       []key.DeletionBlocker{np1, np2},
+			expectUnwarned: nil,
+		},
+		{
+			name:           "case 2: recreated blockers are warned about again",
+			blockers:       []key.DeletionBlocker{recreated, np2},
+			expectUnwarned: []string{"MachineDeployment/np1/1h1m0s"},
+		},
+	}
+
+	cl := &apiv1alpha2.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "a2wax",
+			Namespace: "default",
+		},
+	}
+
+	d := &DeletionBlocker{
+		warned: map[string]struct{}{},
+	}
+
+	// The test cases run in order against the same deletion blocker, so that
+	// the warned blockers of previous reports are taken into account.
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			u := d.unwarned(cl, "MachineDeployment", tc.blockers, exceeded(tc.blockers, now, time.Hour))
+			if !reflect.DeepEqual(blockerIDs(u, now), tc.expectUnwarned) {
+				t.Fatalf("expected %#v to be equal to %#v", tc.expectUnwarned, blockerIDs(u, now))
+			}
+		})
+	}
+}
+
+func blockerIDs(blockers []key.DeletionBlocker, now time.Time) []string {
+	var ids []string
+	for _, b := range blockers {
+		ids = append(ids, b.Kind+"/"+b.Name+"/"+key.DeletionBlockerAge(b, now).String())
+	}
+
+	return ids
+}
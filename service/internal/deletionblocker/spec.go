@@ -0,0 +1,16 @@
+package deletionblocker
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type Interface interface {
+	// Report records the given objects of the given kind as the ones blocking
+	// the deletion of the tenant cluster represented by the given Cluster CR.
+	// Previously reported objects of the same kind are replaced. Warning events
+	// are emitted once for objects blocking for longer than the configured age.
+	// Objects other than Cluster CRs are ignored.
+	Report(ctx context.Context, obj interface{}, kind string, objs []metav1.Object) error
+}
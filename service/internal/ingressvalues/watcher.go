@@ -0,0 +1,171 @@
+package ingressvalues
+
+import (
+	"context"
+	"time"
+
+	"github.com/giantswarm/k8sclient/v5/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/pkg/project"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+const (
+	// resyncPeriod is the interval in which all user values config maps are
+	// handled again, which retries failed triggers.
+	resyncPeriod = 5 * time.Minute
+)
+
+type Config struct {
+	K8sClient k8sclient.Interface
+	Logger    micrologger.Logger
+}
+
+// Watcher watches the ingress controller user values config maps customers
+// use to tune the ingress controllers of their tenant clusters. The Cluster CR
+// is not reconciled when these config maps change. The watcher propagates the
+// resource version of the config map to the following annotation of the
+// Cluster CR, which ensures the ingress controller values of the tenant
+// cluster reflect the user values right away.
+//
+//     cluster-operator.giantswarm.io/ingress-controller-user-values-version
+//
+type Watcher struct {
+	k8sClient k8sclient.Interface
+	logger    micrologger.Logger
+}
+
+func New(config Config) (*Watcher, error) {
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	w := &Watcher{
+		k8sClient: config.K8sClient,
+		logger:    config.Logger,
+	}
+
+	return w, nil
+}
+
+// Boot watches the ingress controller user values config maps until the given
+// context is done. Only config maps of that name are watched, so that the
+// informer does not cache all config maps of the management cluster.
+func (w *Watcher) Boot(ctx context.Context) {
+	factory := informers.NewSharedInformerFactoryWithOptions(
+		w.k8sClient.K8sClient(),
+		resyncPeriod,
+		informers.WithTweakListOptions(func(o *metav1.ListOptions) {
+			o.FieldSelector = fields.OneTermEqualSelector("metadata.name", key.IngressControllerUserValuesName).String()
+		}),
+	)
+
+	informer := factory.Core().V1().ConfigMaps().Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			w.handle(ctx, obj, false)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			w.handle(ctx, newObj, false)
+		},
+		DeleteFunc: func(obj interface{}) {
+			w.handle(ctx, obj, true)
+		},
+	})
+
+	factory.Start(ctx.Done())
+	<-ctx.Done()
+}
+
+func (w *Watcher) handle(ctx context.Context, obj interface{}, deleted bool) {
+	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+
+	cm, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return
+	}
+
+	var version string
+	if !deleted {
+		version = cm.GetResourceVersion()
+	}
+
+	// The config map lives in the cluster namespace, which is named after the
+	// cluster ID.
+	err := w.trigger(ctx, cm.GetNamespace(), version)
+	if err != nil {
+		w.logger.Errorf(ctx, err, "failed to propagate user values of tenant cluster %#q", cm.GetNamespace())
+	}
+}
+
+// trigger annotates the Cluster CRs of the given tenant cluster reconciled by
+// this operator version with the given user values version. The annotation is
+// removed when the version is empty.
+func (w *Watcher) trigger(ctx context.Context, clusterID string, version string) error {
+	var list apiv1alpha2.ClusterList
+	{
+		err := w.k8sClient.CtrlClient().List(
+			ctx,
+			&list,
+			client.MatchingLabels{
+				label.Cluster:         clusterID,
+				label.OperatorVersion: project.Version(),
+			},
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	for _, cl := range list.Items {
+		cl := cl // dereferencing pointer value into new scope
+
+		if key.IsDeleted(&cl) {
+			continue
+		}
+
+		if cl.GetAnnotations()[annotation.IngressControllerUserValuesVersion] == version {
+			continue
+		}
+
+		w.logger.Debugf(ctx, "updating annotation %#q of Cluster CR for tenant cluster %#q", annotation.IngressControllerUserValuesVersion, clusterID)
+
+		patch := client.MergeFrom(cl.DeepCopy())
+
+		a := cl.GetAnnotations()
+		if a == nil {
+			a = map[string]string{}
+		}
+		if version == "" {
+			delete(a, annotation.IngressControllerUserValuesVersion)
+		} else {
+			a[annotation.IngressControllerUserValuesVersion] = version
+		}
+		cl.SetAnnotations(a)
+
+		err := w.k8sClient.CtrlClient().Patch(ctx, &cl, patch)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		w.logger.Debugf(ctx, "updated annotation %#q of Cluster CR for tenant cluster %#q", annotation.IngressControllerUserValuesVersion, clusterID)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package ingressvalues
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/giantswarm/k8sclient/v5/pkg/k8sclienttest"
+	"github.com/giantswarm/micrologger/microloggertest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/pkg/project"
+)
+
+func Test_Watcher_trigger(t *testing.T) {
+	testCases := []struct {
+		name               string
+		annotations        map[string]string
+		operatorVersion    string
+		version            string
+		expectedAnnotation string
+		expectedAnnotated  bool
+	}{
+		{
+			name:               "case 0: user values created",
+			annotations:        nil,
+			operatorVersion:    project.Version(),
+			version:            "42",
+			expectedAnnotation: "42",
+			expectedAnnotated:  true,
+		},
+		{
+			name: "case 1: user values updated",
+			annotations: map[string]string{
+				annotation.IngressControllerUserValuesVersion: "41",
+			},
+			operatorVersion:    project.Version(),
+			version:            "42",
+			expectedAnnotation: "42",
+			expectedAnnotated:  true,
+		},
+		{
+			name: "case 2: user values deleted",
+			annotations: map[string]string{
+				annotation.IngressControllerUserValuesVersion: "42",
+			},
+			operatorVersion:    project.Version(),
+			version:            "",
+			expectedAnnotation: "",
+			expectedAnnotated:  false,
+		},
+		{
+			name:               "case 3: cluster of other operator version",
+			annotations:        nil,
+			operatorVersion:    "0.0.1",
+			version:            "42",
+			expectedAnnotation: "",
+			expectedAnnotated:  false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var err error
+			ctx := context.Background()
+
+			cl := &apiv1alpha2.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "a2wax",
+					Namespace:   "default",
+					Annotations: tc.annotations,
+					Labels: map[string]string{
+						label.Cluster:         "a2wax",
+						label.OperatorVersion: tc.operatorVersion,
+					},
+				},
+			}
+
+			var w *Watcher
+			{
+				scheme := runtime.NewScheme()
+				err = apiv1alpha2.AddToScheme(scheme)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				c := Config{
+					K8sClient: k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+						CtrlClient: fake.NewFakeClientWithScheme(scheme, cl),
+					}),
+					Logger: microloggertest.New(),
+				}
+
+				w, err = New(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err = w.trigger(ctx, "a2wax", tc.version)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var updated apiv1alpha2.Cluster
+			err = w.k8sClient.CtrlClient().Get(ctx, types.NamespacedName{Name: "a2wax", Namespace: "default"}, &updated)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			v, ok := updated.GetAnnotations()[annotation.IngressControllerUserValuesVersion]
+			if ok != tc.expectedAnnotated {
+				t.Fatalf("expected %t to be equal to %t", tc.expectedAnnotated, ok)
+			}
+			if v != tc.expectedAnnotation {
+				t.Fatalf("expected %#q to be equal to %#q", tc.expectedAnnotation, v)
+			}
+		})
+	}
+}
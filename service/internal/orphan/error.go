@@ -0,0 +1,28 @@
+package orphan
+
+import (
+	"github.com/giantswarm/microerror"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+// isNotInstalled asserts errors returned when listing CRs of kinds whose CRDs
+// are not installed in the management cluster.
+func isNotInstalled(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	c := microerror.Cause(err)
+
+	return apierrors.IsNotFound(c) || meta.IsNoMatchError(c)
+}
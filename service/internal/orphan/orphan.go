@@ -0,0 +1,347 @@
+package orphan
+
+import (
+	"context"
+	"fmt"
+
+	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
+	"github.com/giantswarm/k8sclient/v5/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/pkg/project"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+const (
+	KindApp        = "App"
+	KindCertConfig = "CertConfig"
+	KindConfigMap  = "ConfigMap"
+	KindNamespace  = "Namespace"
+	KindSecret     = "Secret"
+)
+
+// Object is an object in the management cluster belonging to a tenant
+// cluster.
+type Object struct {
+	ClusterID string
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+func (o Object) String() string {
+	if o.Namespace == "" {
+		return fmt.Sprintf("%s %s", o.Kind, o.Name)
+	}
+
+	return fmt.Sprintf("%s %s/%s", o.Kind, o.Namespace, o.Name)
+}
+
+type Config struct {
+	K8sClient k8sclient.Interface
+}
+
+type Orphan struct {
+	k8sClient k8sclient.Interface
+}
+
+func New(c Config) (*Orphan, error) {
+	if c.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", c)
+	}
+
+	o := &Orphan{
+		k8sClient: c.K8sClient,
+	}
+
+	return o, nil
+}
+
+func (o *Orphan) Find(ctx context.Context) ([]Object, error) {
+	// Objects are listed before the tenant clusters, so that objects of tenant
+	// clusters created in between are never considered orphaned.
+	objs, err := o.managedObjects(ctx)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	clusterIDs, err := o.clusterIDs(ctx)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return orphaned(objs, clusterIDs), nil
+}
+
+func (o *Orphan) Delete(ctx context.Context, obj Object) error {
+	var err error
+	switch obj.Kind {
+	case KindApp:
+		err = o.k8sClient.G8sClient().ApplicationV1alpha1().Apps(obj.Namespace).Delete(ctx, obj.Name, metav1.DeleteOptions{})
+	case KindCertConfig:
+		err = o.k8sClient.G8sClient().CoreV1alpha1().CertConfigs(obj.Namespace).Delete(ctx, obj.Name, metav1.DeleteOptions{})
+	case KindConfigMap:
+		err = o.k8sClient.K8sClient().CoreV1().ConfigMaps(obj.Namespace).Delete(ctx, obj.Name, metav1.DeleteOptions{})
+	case KindNamespace:
+		err = o.k8sClient.K8sClient().CoreV1().Namespaces().Delete(ctx, obj.Name, metav1.DeleteOptions{})
+	case KindSecret:
+		err = o.k8sClient.K8sClient().CoreV1().Secrets(obj.Namespace).Delete(ctx, obj.Name, metav1.DeleteOptions{})
+	default:
+		return microerror.Maskf(invalidConfigError, "unknown kind %#q", obj.Kind)
+	}
+
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// clusterIDs returns the IDs of all tenant clusters known by any of the CRs
+// which can make up a tenant cluster, regardless of the operator version
+// reconciling them. Objects of a tenant cluster must never be considered
+// orphaned as long as any of its CRs exists, e.g. while the Cluster CR of a
+// node pool cluster is recreated or for legacy clusters without Cluster CR.
+// CRs of kinds not installed in the management cluster are skipped.
+func (o *Orphan) clusterIDs(ctx context.Context) (map[string]bool, error) {
+	ids := map[string]bool{}
+
+	// Cluster API and infrastructure CRs carry the cluster ID label.
+	{
+		lists := []runtime.Object{
+			&apiv1alpha2.ClusterList{},
+			&apiv1alpha2.MachineDeploymentList{},
+			&infrastructurev1alpha2.AWSClusterList{},
+			&infrastructurev1alpha2.AWSControlPlaneList{},
+			&infrastructurev1alpha2.AWSMachineDeploymentList{},
+			&infrastructurev1alpha2.G8sControlPlaneList{},
+		}
+
+		for _, list := range lists {
+			err := o.k8sClient.CtrlClient().List(ctx, list)
+			if isNotInstalled(err) {
+				continue
+			} else if err != nil {
+				return nil, microerror.Mask(err)
+			}
+
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+
+			for _, i := range items {
+				m, err := meta.Accessor(i)
+				if err != nil {
+					return nil, microerror.Mask(err)
+				}
+
+				addClusterID(ids, key.ClusterID(m))
+			}
+		}
+	}
+
+	// Legacy provider and cluster config CRs carry the cluster ID in their
+	// spec.
+	{
+		list, err := o.k8sClient.G8sClient().ProviderV1alpha1().AWSConfigs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		if isNotInstalled(err) {
+			// fall through
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		} else {
+			for _, i := range list.Items {
+				addClusterID(ids, i.Spec.Cluster.ID)
+			}
+		}
+	}
+
+	{
+		list, err := o.k8sClient.G8sClient().ProviderV1alpha1().AzureConfigs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		if isNotInstalled(err) {
+			// fall through
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		} else {
+			for _, i := range list.Items {
+				addClusterID(ids, i.Spec.Cluster.ID)
+			}
+		}
+	}
+
+	{
+		list, err := o.k8sClient.G8sClient().ProviderV1alpha1().KVMConfigs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		if isNotInstalled(err) {
+			// fall through
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		} else {
+			for _, i := range list.Items {
+				addClusterID(ids, i.Spec.Cluster.ID)
+			}
+		}
+	}
+
+	{
+		list, err := o.k8sClient.G8sClient().CoreV1alpha1().AWSClusterConfigs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		if isNotInstalled(err) {
+			// fall through
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		} else {
+			for _, i := range list.Items {
+				addClusterID(ids, i.Spec.Guest.ID)
+			}
+		}
+	}
+
+	{
+		list, err := o.k8sClient.G8sClient().CoreV1alpha1().AzureClusterConfigs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		if isNotInstalled(err) {
+			// fall through
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		} else {
+			for _, i := range list.Items {
+				addClusterID(ids, i.Spec.Guest.ID)
+			}
+		}
+	}
+
+	{
+		list, err := o.k8sClient.G8sClient().CoreV1alpha1().KVMClusterConfigs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		if isNotInstalled(err) {
+			// fall through
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		} else {
+			for _, i := range list.Items {
+				addClusterID(ids, i.Spec.Guest.ID)
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// managedObjects returns all objects managed by cluster-operator which belong
+// to a tenant cluster.
+func (o *Orphan) managedObjects(ctx context.Context) ([]Object, error) {
+	managed := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s,%s", label.ManagedBy, project.Name(), label.Cluster),
+	}
+
+	var objs []Object
+
+	{
+		list, err := o.k8sClient.G8sClient().ApplicationV1alpha1().Apps(metav1.NamespaceAll).List(ctx, managed)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		for _, i := range list.Items {
+			objs = appendObject(objs, KindApp, &i.ObjectMeta)
+		}
+	}
+
+	{
+		list, err := o.k8sClient.G8sClient().CoreV1alpha1().CertConfigs(metav1.NamespaceAll).List(ctx, managed)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		for _, i := range list.Items {
+			objs = appendObject(objs, KindCertConfig, &i.ObjectMeta)
+		}
+	}
+
+	{
+		list, err := o.k8sClient.K8sClient().CoreV1().ConfigMaps(metav1.NamespaceAll).List(ctx, managed)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		for _, i := range list.Items {
+			objs = appendObject(objs, KindConfigMap, &i.ObjectMeta)
+		}
+	}
+
+	{
+		list, err := o.k8sClient.K8sClient().CoreV1().Secrets(metav1.NamespaceAll).List(ctx, managed)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		for _, i := range list.Items {
+			objs = appendObject(objs, KindSecret, &i.ObjectMeta)
+		}
+	}
+
+	// Cluster namespaces are named after the ID of their tenant cluster, which
+	// distinguishes them from other namespaces labelled with a cluster ID.
+	// Cluster namespaces created before they got the managed-by label are
+	// never considered orphaned.
+	{
+		list, err := o.k8sClient.K8sClient().CoreV1().Namespaces().List(ctx, managed)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		for _, i := range list.Items {
+			if i.GetName() != i.GetLabels()[label.Cluster] {
+				continue
+			}
+
+			objs = appendObject(objs, KindNamespace, &i.ObjectMeta)
+		}
+	}
+
+	return objs, nil
+}
+
+func addClusterID(ids map[string]bool, id string) {
+	if id == "" {
+		return
+	}
+
+	ids[id] = true
+}
+
+func appendObject(objs []Object, kind string, m metav1.Object) []Object {
+	// Objects being deleted are not orphaned anymore.
+	if m.GetDeletionTimestamp() != nil {
+		return objs
+	}
+
+	o := Object{
+		ClusterID: m.GetLabels()[label.Cluster],
+		Kind:      kind,
+		Name:      m.GetName(),
+		Namespace: m.GetNamespace(),
+	}
+
+	return append(objs, o)
+}
+
+// orphaned returns the given objects whose tenant clusters are not in the
+// given set of cluster IDs.
+func orphaned(objs []Object, clusterIDs map[string]bool) []Object {
+	var result []Object
+	for _, o := range objs {
+		if o.ClusterID == "" || clusterIDs[o.ClusterID] {
+			continue
+		}
+
+		result = append(result, o)
+	}
+
+	return result
+}
@@ -0,0 +1,57 @@
+package orphan
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func Test_orphaned(t *testing.T) {
+	testCases := []struct {
+		name       string
+		objs       []Object
+		clusterIDs map[string]bool
+		expected   []Object
+	}{
+		{
+			name: "case 0: objects of existing clusters are not orphaned",
+			objs: []Object{
+				{ClusterID: "al9qy", Kind: KindApp, Name: "coredns", Namespace: "al9qy"},
+				{ClusterID: "al9qy", Kind: KindNamespace, Name: "al9qy"},
+			},
+			clusterIDs: map[string]bool{"al9qy": true},
+			expected:   nil,
+		},
+		{
+			name: "case 1: objects of deleted clusters are orphaned",
+			objs: []Object{
+				{ClusterID: "al9qy", Kind: KindApp, Name: "coredns", Namespace: "al9qy"},
+				{ClusterID: "x5m2p", Kind: KindCertConfig, Name: "x5m2p-api", Namespace: "default"},
+				{ClusterID: "x5m2p", Kind: KindSecret, Name: "x5m2p-kubeconfig", Namespace: "x5m2p"},
+			},
+			clusterIDs: map[string]bool{"al9qy": true},
+			expected: []Object{
+				{ClusterID: "x5m2p", Kind: KindCertConfig, Name: "x5m2p-api", Namespace: "default"},
+				{ClusterID: "x5m2p", Kind: KindSecret, Name: "x5m2p-kubeconfig", Namespace: "x5m2p"},
+			},
+		},
+		{
+			name: "case 2: objects without cluster ID are ignored",
+			objs: []Object{
+				{ClusterID: "", Kind: KindConfigMap, Name: "cluster-values", Namespace: "default"},
+			},
+			clusterIDs: map[string]bool{},
+			expected:   nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			result := orphaned(tc.objs, tc.clusterIDs)
+
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Fatalf("expected %#v to be equal to %#v", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package orphan
+
+import "context"
+
+type Interface interface {
+	// Find returns the objects managed by cluster-operator in the management
+	// cluster whose tenant cluster does not exist anymore.
+	Find(ctx context.Context) ([]Object, error)
+	// Delete deletes the given orphaned object. Objects which do not exist
+	// anymore are ignored.
+	Delete(ctx context.Context, o Object) error
+}
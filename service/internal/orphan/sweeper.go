@@ -0,0 +1,181 @@
+package orphan
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+)
+
+// Orphaned is an orphaned object together with the time the sweeper found it
+// first.
+type Orphaned struct {
+	Object
+	FirstSeen time.Time
+}
+
+type SweeperConfig struct {
+	Logger micrologger.Logger
+	Orphan Interface
+
+	// Enforcing enables the deletion of orphaned objects. Orphaned objects are
+	// only reported when disabled.
+	Enforcing bool
+	// GracePeriod is the duration an object has to be orphaned before it is
+	// deleted in enforcing mode.
+	GracePeriod time.Duration
+	// Interval is the duration between two sweeps.
+	Interval time.Duration
+}
+
+// Sweeper periodically looks for orphaned objects and deletes them in
+// enforcing mode once they are orphaned for longer than the grace period.
+type Sweeper struct {
+	logger micrologger.Logger
+	orphan Interface
+
+	enforcing   bool
+	gracePeriod time.Duration
+	interval    time.Duration
+
+	mutex    sync.Mutex
+	orphaned map[Object]time.Time
+}
+
+func NewSweeper(config SweeperConfig) (*Sweeper, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Orphan == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Orphan must not be empty", config)
+	}
+
+	if config.GracePeriod < 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.GracePeriod must not be negative", config)
+	}
+	if config.Interval <= 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Interval must be positive", config)
+	}
+
+	s := &Sweeper{
+		logger: config.Logger,
+		orphan: config.Orphan,
+
+		enforcing:   config.Enforcing,
+		gracePeriod: config.GracePeriod,
+		interval:    config.Interval,
+
+		orphaned: map[Object]time.Time{},
+	}
+
+	return s, nil
+}
+
+// Boot sweeps orphaned objects every interval until the given context is
+// done.
+func (s *Sweeper) Boot(ctx context.Context) {
+	t := time.NewTicker(s.interval)
+	defer t.Stop()
+
+	for {
+		err := s.Sweep(ctx)
+		if err != nil {
+			s.logger.Errorf(ctx, err, "failed to sweep orphaned objects")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Orphaned returns the orphaned objects found by the last sweep.
+func (s *Sweeper) Orphaned() []Orphaned {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var list []Orphaned
+	for o, t := range s.orphaned {
+		list = append(list, Orphaned{Object: o, FirstSeen: t})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].String() < list[j].String()
+	})
+
+	return list
+}
+
+// Sweep looks for orphaned objects once and, in enforcing mode, deletes the
+// ones orphaned for longer than the grace period.
+func (s *Sweeper) Sweep(ctx context.Context) error {
+	s.logger.Debugf(ctx, "finding orphaned objects")
+
+	objs, err := s.orphan.Find(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	s.logger.Debugf(ctx, "found %d orphaned objects", len(objs))
+
+	s.mutex.Lock()
+	expired := s.track(time.Now(), objs)
+	s.mutex.Unlock()
+
+	if !s.enforcing {
+		return nil
+	}
+
+	for _, o := range expired {
+		s.logger.Debugf(ctx, "deleting orphaned %s of cluster %#q", o, o.ClusterID)
+
+		err = s.orphan.Delete(ctx, o)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		s.mutex.Lock()
+		delete(s.orphaned, o)
+		s.mutex.Unlock()
+
+		s.logger.Debugf(ctx, "deleted orphaned %s of cluster %#q", o, o.ClusterID)
+	}
+
+	return nil
+}
+
+// track records the given objects as orphaned, forgets the objects which are
+// not orphaned anymore and returns the objects orphaned for longer than the
+// grace period. The caller must hold the mutex.
+func (s *Sweeper) track(now time.Time, objs []Object) []Object {
+	found := map[Object]bool{}
+	for _, o := range objs {
+		found[o] = true
+	}
+
+	for o := range s.orphaned {
+		if !found[o] {
+			delete(s.orphaned, o)
+		}
+	}
+
+	var expired []Object
+	for _, o := range objs {
+		t, ok := s.orphaned[o]
+		if !ok {
+			t = now
+			s.orphaned[o] = t
+		}
+
+		if now.Sub(t) >= s.gracePeriod {
+			expired = append(expired, o)
+		}
+	}
+
+	return expired
+}
@@ -0,0 +1,300 @@
+package orphan
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
+	providerv1alpha1 "github.com/giantswarm/apiextensions/v3/pkg/apis/provider/v1alpha1"
+	fakeg8s "github.com/giantswarm/apiextensions/v3/pkg/clientset/versioned/fake"
+	"github.com/giantswarm/k8sclient/v5/pkg/k8sclienttest"
+	"github.com/giantswarm/micrologger/microloggertest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/pkg/project"
+)
+
+type fakeOrphan struct {
+	deleted []Object
+	found   []Object
+}
+
+func (f *fakeOrphan) Find(ctx context.Context) ([]Object, error) {
+	return f.found, nil
+}
+
+func (f *fakeOrphan) Delete(ctx context.Context, o Object) error {
+	f.deleted = append(f.deleted, o)
+	return nil
+}
+
+func Test_Sweeper_Sweep(t *testing.T) {
+	app := Object{ClusterID: "x5m2p", Kind: KindApp, Name: "coredns", Namespace: "x5m2p"}
+	secret := Object{ClusterID: "x5m2p", Kind: KindSecret, Name: "x5m2p-kubeconfig", Namespace: "x5m2p"}
+
+	testCases := []struct {
+		name            string
+		enforcing       bool
+		orphaned        map[Object]time.Duration
+		found           []Object
+		expectDeleted   []Object
+		expectRemaining []Object
+	}{
+		{
+			name:            "case 0: new orphans are tracked",
+			enforcing:       true,
+			found:           []Object{app},
+			expectDeleted:   nil,
+			expectRemaining: []Object{app},
+		},
+		{
+			name:            "case 1: orphans within the grace period are kept",
+			enforcing:       true,
+			orphaned:        map[Object]time.Duration{app: 30 * time.Minute},
+			found:           []Object{app},
+			expectDeleted:   nil,
+			expectRemaining: []Object{app},
+		},
+		{
+			name:            "case 2: expired orphans are deleted in enforcing mode",
+			enforcing:       true,
+			orphaned:        map[Object]time.Duration{app: 2 * time.Hour, secret: 30 * time.Minute},
+			found:           []Object{app, secret},
+			expectDeleted:   []Object{app},
+			expectRemaining: []Object{secret},
+		},
+		{
+			name:            "case 3: expired orphans are only reported when not enforcing",
+			enforcing:       false,
+			orphaned:        map[Object]time.Duration{app: 2 * time.Hour},
+			found:           []Object{app},
+			expectDeleted:   nil,
+			expectRemaining: []Object{app},
+		},
+		{
+			name:            "case 4: objects not orphaned anymore are forgotten",
+			enforcing:       true,
+			orphaned:        map[Object]time.Duration{app: 2 * time.Hour},
+			found:           nil,
+			expectDeleted:   nil,
+			expectRemaining: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var err error
+
+			f := &fakeOrphan{found: tc.found}
+
+			var s *Sweeper
+			{
+				c := SweeperConfig{
+					Logger: microloggertest.New(),
+					Orphan: f,
+
+					Enforcing:   tc.enforcing,
+					GracePeriod: time.Hour,
+					Interval:    time.Minute,
+				}
+
+				s, err = NewSweeper(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for o, age := range tc.orphaned {
+				s.orphaned[o] = time.Now().Add(-age)
+			}
+
+			err = s.Sweep(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(f.deleted, tc.expectDeleted) {
+				t.Fatalf("expected deleted %#v to be equal to %#v", tc.expectDeleted, f.deleted)
+			}
+
+			var remaining []Object
+			for _, o := range s.Orphaned() {
+				remaining = append(remaining, o.Object)
+			}
+
+			if !reflect.DeepEqual(remaining, tc.expectRemaining) {
+				t.Fatalf("expected remaining %#v to be equal to %#v", tc.expectRemaining, remaining)
+			}
+		})
+	}
+}
+
+func Test_Sweeper_Sweep_namespaces(t *testing.T) {
+	testCases := []struct {
+		name          string
+		namespace     *corev1.Namespace
+		ctrlObjects   []runtime.Object
+		g8sObjects    []runtime.Object
+		expectDeleted bool
+	}{
+		{
+			name:          "case 0: namespace of a cluster without CRs is deleted",
+			namespace:     newNamespace("x5m2p", true),
+			expectDeleted: true,
+		},
+		{
+			name:      "case 1: namespace of a cluster known by its Cluster CR is kept",
+			namespace: newNamespace("x5m2p", true),
+			ctrlObjects: []runtime.Object{
+				&apiv1alpha2.Cluster{
+					ObjectMeta: newClusterObjectMeta("x5m2p"),
+				},
+			},
+			expectDeleted: false,
+		},
+		{
+			name:      "case 2: namespace of a cluster known by its G8sControlPlane CR is kept",
+			namespace: newNamespace("x5m2p", true),
+			ctrlObjects: []runtime.Object{
+				&infrastructurev1alpha2.G8sControlPlane{
+					ObjectMeta: newClusterObjectMeta("x5m2p"),
+				},
+			},
+			expectDeleted: false,
+		},
+		{
+			name:      "case 3: namespace of a cluster known by its MachineDeployment CR is kept",
+			namespace: newNamespace("x5m2p", true),
+			ctrlObjects: []runtime.Object{
+				&apiv1alpha2.MachineDeployment{
+					ObjectMeta: newClusterObjectMeta("x5m2p"),
+				},
+			},
+			expectDeleted: false,
+		},
+		{
+			name:      "case 4: namespace of a legacy cluster known by its AWSConfig CR is kept",
+			namespace: newNamespace("x5m2p", true),
+			g8sObjects: []runtime.Object{
+				&providerv1alpha1.AWSConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "x5m2p", Namespace: "default"},
+					Spec: providerv1alpha1.AWSConfigSpec{
+						Cluster: providerv1alpha1.Cluster{ID: "x5m2p"},
+					},
+				},
+			},
+			expectDeleted: false,
+		},
+		{
+			name:          "case 5: namespace without managed-by label is kept",
+			namespace:     newNamespace("x5m2p", false),
+			expectDeleted: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var err error
+			ctx := context.Background()
+
+			var k8sClient *k8sclienttest.Clients
+			{
+				scheme := runtime.NewScheme()
+				err = apiv1alpha2.AddToScheme(scheme)
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = infrastructurev1alpha2.AddToScheme(scheme)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				k8sClient = k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+					CtrlClient: fake.NewFakeClientWithScheme(scheme, tc.ctrlObjects...),
+					G8sClient:  fakeg8s.NewSimpleClientset(tc.g8sObjects...),
+					K8sClient:  fakek8s.NewSimpleClientset(tc.namespace),
+				})
+			}
+
+			var o *Orphan
+			{
+				c := Config{
+					K8sClient: k8sClient,
+				}
+
+				o, err = New(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var s *Sweeper
+			{
+				c := SweeperConfig{
+					Logger: microloggertest.New(),
+					Orphan: o,
+
+					Enforcing:   true,
+					GracePeriod: 0,
+					Interval:    time.Minute,
+				}
+
+				s, err = NewSweeper(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err = s.Sweep(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			list, err := k8sClient.K8sClient().CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			deleted := len(list.Items) == 0
+			if deleted != tc.expectDeleted {
+				t.Fatalf("expected %t to be equal to %t", tc.expectDeleted, deleted)
+			}
+		})
+	}
+}
+
+func newClusterObjectMeta(id string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      id,
+		Namespace: "default",
+		Labels: map[string]string{
+			label.Cluster: id,
+		},
+	}
+}
+
+func newNamespace(id string, managed bool) *corev1.Namespace {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: id,
+			Labels: map[string]string{
+				label.Cluster: id,
+			},
+		},
+	}
+
+	if managed {
+		ns.Labels[label.ManagedBy] = project.Name()
+	}
+
+	return ns
+}
@@ -0,0 +1,228 @@
+package podcidr
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+
+	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
+	"github.com/giantswarm/microerror"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+// usedCIDR is a pod CIDR in use by the installation or some Tenant Cluster.
+type usedCIDR struct {
+	cidr  *net.IPNet
+	owner string
+}
+
+func (p *PodCIDR) Allocate(ctx context.Context, obj interface{}) (string, error) {
+	if p.pool == nil {
+		return "", nil
+	}
+
+	cr, err := meta.Accessor(obj)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	// We do not use the cached AWSCluster CR here, because we must never
+	// allocate twice for the same Tenant Cluster.
+	cl, err := p.lookupCluster(ctx, cr)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	if cl.Spec.Provider.Pods.CIDRBlock != "" {
+		return "", nil
+	}
+
+	// Tenant Clusters already being created or running rely on the
+	// installation pod CIDR. Allocating a pod CIDR for them would re-IP their
+	// pods, so we only allocate for Tenant Clusters not created yet.
+	if cl.Status.Cluster.HasCreatingCondition() || cl.Status.Cluster.HasCreatedCondition() {
+		return "", nil
+	}
+
+	used, err := p.usedCIDRs(ctx, cl)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	var allocated *net.IPNet
+	{
+		_, bits := p.pool.Mask.Size()
+		candidate := &net.IPNet{IP: p.pool.IP, Mask: net.CIDRMask(p.poolPrefixLength, bits)}
+
+		for candidate != nil && p.pool.Contains(candidate.IP) {
+			if len(overlapping(candidate, used)) == 0 {
+				allocated = candidate
+				break
+			}
+
+			candidate = nextCIDR(candidate)
+		}
+
+		if allocated == nil {
+			return "", microerror.Maskf(poolExhaustedError, "pool %#q", p.pool.String())
+		}
+	}
+
+	{
+		cl.Spec.Provider.Pods.CIDRBlock = allocated.String()
+
+		a := cl.GetAnnotations()
+		if a == nil {
+			a = map[string]string{}
+		}
+		a[annotation.PodCIDRAllocation] = allocated.String()
+		cl.SetAnnotations(a)
+
+		err = p.k8sClient.CtrlClient().Update(ctx, &cl)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
+	}
+
+	// The allocation has to be visible to the resources reconciling the Tenant
+	// Cluster after us within the same reconciliation loop.
+	{
+		ck := p.clusterCache.Key(ctx, cr)
+		if ck != "" {
+			p.clusterCache.Set(ctx, ck, cl)
+		}
+	}
+
+	return allocated.String(), nil
+}
+
+func (p *PodCIDR) Overlaps(ctx context.Context, obj interface{}) ([]string, error) {
+	cr, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	cl, err := p.lookupCluster(ctx, cr)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if cl.Spec.Provider.Pods.CIDRBlock == "" {
+		return nil, nil
+	}
+
+	cidrs, err := parseCIDRs(cl.Spec.Provider.Pods.CIDRBlock)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	used, err := p.usedCIDRs(ctx, cl)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var overlaps []string
+	for _, c := range cidrs {
+		overlaps = append(overlaps, overlapping(c, used)...)
+	}
+
+	return overlaps, nil
+}
+
+// usedCIDRs returns the installation pod CIDR and the pod CIDRs configured for
+// all Tenant Clusters but the given one.
+func (p *PodCIDR) usedCIDRs(ctx context.Context, cl infrastructurev1alpha2.AWSCluster) ([]usedCIDR, error) {
+	var used []usedCIDR
+	{
+		cidrs, err := parseCIDRs(p.installationCIDR)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		for _, c := range cidrs {
+			used = append(used, usedCIDR{cidr: c, owner: "installation pod CIDR"})
+		}
+	}
+
+	var list infrastructurev1alpha2.AWSClusterList
+	{
+		err := p.k8sClient.CtrlClient().List(ctx, &list)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	for _, o := range list.Items {
+		if o.GetNamespace() == cl.GetNamespace() && o.GetName() == cl.GetName() {
+			continue
+		}
+		if o.Spec.Provider.Pods.CIDRBlock == "" {
+			continue
+		}
+
+		// Invalid pod CIDRs of other Tenant Clusters are their problem and are
+		// reported when reconciling them.
+		cidrs, err := parseCIDRs(o.Spec.Provider.Pods.CIDRBlock)
+		if err != nil {
+			continue
+		}
+
+		for _, c := range cidrs {
+			used = append(used, usedCIDR{cidr: c, owner: fmt.Sprintf("pod CIDR of cluster %#q", key.ClusterID(&o))})
+		}
+	}
+
+	return used, nil
+}
+
+// nextCIDR returns the CIDR of the same size directly following the given one,
+// or nil if there is no such CIDR.
+func nextCIDR(c *net.IPNet) *net.IPNet {
+	ones, bits := c.Mask.Size()
+
+	i := new(big.Int).SetBytes(c.IP)
+	i.Add(i, new(big.Int).Lsh(big.NewInt(1), uint(bits-ones)))
+
+	b := i.Bytes()
+	if len(b) > len(c.IP) {
+		return nil
+	}
+
+	ip := make(net.IP, len(c.IP))
+	copy(ip[len(ip)-len(b):], b)
+
+	return &net.IPNet{IP: ip, Mask: c.Mask}
+}
+
+// overlapping returns a description of every used CIDR overlapping the given
+// CIDR.
+func overlapping(c *net.IPNet, used []usedCIDR) []string {
+	var overlaps []string
+	for _, u := range used {
+		if c.Contains(u.cidr.IP) || u.cidr.Contains(c.IP) {
+			overlaps = append(overlaps, fmt.Sprintf("%s %#q", u.owner, u.cidr.String()))
+		}
+	}
+
+	return overlaps
+}
+
+// parseCIDRs parses the given comma separated list of CIDRs.
+func parseCIDRs(s string) ([]*net.IPNet, error) {
+	var cidrs []*net.IPNet
+	for _, v := range strings.Split(s, ",") {
+		_, c, err := net.ParseCIDR(strings.TrimSpace(v))
+		if err != nil {
+			return nil, microerror.Maskf(invalidConfigError, "invalid pod CIDR %#q: %s", v, err)
+		}
+
+		cidrs = append(cidrs, c)
+	}
+
+	return cidrs, nil
+}
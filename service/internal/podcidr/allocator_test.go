@@ -0,0 +1,249 @@
+package podcidr
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+
+	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/unittest"
+)
+
+func Test_PodCIDR_Allocate(t *testing.T) {
+	testCases := []struct {
+		name              string
+		poolCIDR          string
+		cidrBlock         string
+		otherCIDRBlocks   []string
+		expectAllocated   string
+		expectCIDRBlock   string
+		errorMatcher      func(error) bool
+		expectAnnotations bool
+		conditions        []infrastructurev1alpha2.CommonClusterStatusCondition
+	}{
+		{
+			name:            "case 0: allocation disabled",
+			poolCIDR:        "",
+			expectAllocated: "",
+			expectCIDRBlock: "",
+		},
+		{
+			name:            "case 1: custom pod CIDR is kept",
+			poolCIDR:        "10.128.0.0/14",
+			cidrBlock:       "10.200.0.0/16",
+			expectAllocated: "",
+			expectCIDRBlock: "10.200.0.0/16",
+		},
+		{
+			name:              "case 2: first CIDR of the pool",
+			poolCIDR:          "10.128.0.0/14",
+			expectAllocated:   "10.128.0.0/16",
+			expectCIDRBlock:   "10.128.0.0/16",
+			expectAnnotations: true,
+		},
+		{
+			name:              "case 3: CIDRs used by other clusters are skipped",
+			poolCIDR:          "10.128.0.0/14",
+			otherCIDRBlocks:   []string{"10.128.0.0/16", "10.129.128.0/17"},
+			expectAllocated:   "10.130.0.0/16",
+			expectCIDRBlock:   "10.130.0.0/16",
+			expectAnnotations: true,
+		},
+		{
+			name:            "case 4: pool exhausted",
+			poolCIDR:        "10.128.0.0/15",
+			otherCIDRBlocks: []string{"10.128.0.0/16", "10.129.0.0/16"},
+			errorMatcher:    IsPoolExhausted,
+		},
+		{
+			name:              "case 5: installation pod CIDR is skipped",
+			poolCIDR:          "10.0.0.0/14",
+			expectAllocated:   "10.1.0.0/16",
+			expectCIDRBlock:   "10.1.0.0/16",
+			expectAnnotations: true,
+		},
+		{
+			name:            "case 6: cluster being created keeps installation pod CIDR",
+			poolCIDR:        "10.128.0.0/14",
+			expectAllocated: "",
+			expectCIDRBlock: "",
+			conditions: []infrastructurev1alpha2.CommonClusterStatusCondition{
+				{Condition: infrastructurev1alpha2.ClusterStatusConditionCreating},
+			},
+		},
+		{
+			name:            "case 7: created cluster keeps installation pod CIDR",
+			poolCIDR:        "10.128.0.0/14",
+			expectAllocated: "",
+			expectCIDRBlock: "",
+			conditions: []infrastructurev1alpha2.CommonClusterStatusCondition{
+				{Condition: infrastructurev1alpha2.ClusterStatusConditionUpdated},
+				{Condition: infrastructurev1alpha2.ClusterStatusConditionCreated},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var err error
+			ctx := context.Background()
+
+			var pc *PodCIDR
+			{
+				c := Config{
+					K8sClient: unittest.FakeK8sClient(),
+
+					InstallationCIDR: "10.0.0.0/16",
+					PoolCIDR:         tc.poolCIDR,
+					PoolPrefixLength: 16,
+				}
+
+				pc, err = New(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for j, b := range tc.otherCIDRBlocks {
+				o := unittest.DefaultCluster()
+				o.Name = strconv.Itoa(j)
+				o.Labels[label.Cluster] = strconv.Itoa(j)
+				o.Spec.Provider.Pods.CIDRBlock = b
+
+				err = pc.k8sClient.CtrlClient().Create(ctx, &o)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var cl infrastructurev1alpha2.AWSCluster
+			{
+				cl = unittest.DefaultCluster()
+				cl.Spec.Provider.Pods.CIDRBlock = tc.cidrBlock
+				cl.Status.Cluster.Conditions = tc.conditions
+
+				err = pc.k8sClient.CtrlClient().Create(ctx, &cl)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			allocated, err := pc.Allocate(ctx, &cl)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if tc.errorMatcher != nil {
+				return
+			}
+
+			if allocated != tc.expectAllocated {
+				t.Fatalf("expected %#q to be equal to %#q", tc.expectAllocated, allocated)
+			}
+
+			cl, err = pc.lookupCluster(ctx, &cl)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cl.Spec.Provider.Pods.CIDRBlock != tc.expectCIDRBlock {
+				t.Fatalf("expected %#q to be equal to %#q", tc.expectCIDRBlock, cl.Spec.Provider.Pods.CIDRBlock)
+			}
+			if tc.expectAnnotations && cl.Annotations[annotation.PodCIDRAllocation] != tc.expectCIDRBlock {
+				t.Fatalf("expected annotation %#q to be equal to %#q", cl.Annotations[annotation.PodCIDRAllocation], tc.expectCIDRBlock)
+			}
+		})
+	}
+}
+
+func Test_PodCIDR_Overlaps(t *testing.T) {
+	testCases := []struct {
+		name            string
+		cidrBlock       string
+		otherCIDRBlocks []string
+		expectOverlaps  []string
+	}{
+		{
+			name:           "case 0: installation default is not considered",
+			cidrBlock:      "",
+			expectOverlaps: nil,
+		},
+		{
+			name:            "case 1: no overlap",
+			cidrBlock:       "10.200.0.0/16",
+			otherCIDRBlocks: []string{"10.201.0.0/16"},
+			expectOverlaps:  nil,
+		},
+		{
+			name:            "case 2: overlapping installation and other cluster",
+			cidrBlock:       "10.0.0.0/8",
+			otherCIDRBlocks: []string{"10.201.0.0/16", "192.168.0.0/16"},
+			expectOverlaps: []string{
+				"installation pod CIDR `10.0.0.0/16`",
+				"pod CIDR of cluster `0` `10.201.0.0/16`",
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var err error
+			ctx := context.Background()
+
+			var pc *PodCIDR
+			{
+				c := Config{
+					K8sClient: unittest.FakeK8sClient(),
+
+					InstallationCIDR: "10.0.0.0/16",
+				}
+
+				pc, err = New(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for j, b := range tc.otherCIDRBlocks {
+				o := unittest.DefaultCluster()
+				o.Name = strconv.Itoa(j)
+				o.Labels[label.Cluster] = strconv.Itoa(j)
+				o.Spec.Provider.Pods.CIDRBlock = b
+
+				err = pc.k8sClient.CtrlClient().Create(ctx, &o)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cl := unittest.DefaultCluster()
+			cl.Spec.Provider.Pods.CIDRBlock = tc.cidrBlock
+
+			err = pc.k8sClient.CtrlClient().Create(ctx, &cl)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			overlaps, err := pc.Overlaps(ctx, &cl)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(overlaps, tc.expectOverlaps) {
+				t.Fatalf("expected %#v to be equal to %#v", tc.expectOverlaps, overlaps)
+			}
+		})
+	}
+}
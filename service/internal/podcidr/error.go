@@ -29,3 +29,13 @@ var tooManyCRsError = &microerror.Error{
 func IsTooManyCRsError(err error) bool {
 	return microerror.Cause(err) == tooManyCRsError
 }
+
+var poolExhaustedError = &microerror.Error{
+	Kind: "poolExhaustedError",
+	Desc: "There is no pod CIDR left in the pool, which does not overlap any other pod CIDR.",
+}
+
+// IsPoolExhausted asserts poolExhaustedError.
+func IsPoolExhausted(err error) bool {
+	return microerror.Cause(err) == poolExhaustedError
+}
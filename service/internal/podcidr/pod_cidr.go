@@ -2,6 +2,7 @@ package podcidr
 
 import (
 	"context"
+	"net"
 
 	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
 	"github.com/giantswarm/k8sclient/v5/pkg/k8sclient"
@@ -19,6 +20,12 @@ type Config struct {
 	K8sClient k8sclient.Interface
 
 	InstallationCIDR string
+	// PoolCIDR is the CIDR pod CIDRs are allocated from for Tenant Clusters not
+	// specifying a pod CIDR themselves. Allocation is disabled when empty.
+	PoolCIDR string
+	// PoolPrefixLength is the prefix length of the pod CIDRs allocated from
+	// PoolCIDR.
+	PoolPrefixLength int
 }
 
 type PodCIDR struct {
@@ -27,6 +34,8 @@ type PodCIDR struct {
 	clusterCache *cache.Cluster
 
 	installationCIDR string
+	pool             *net.IPNet
+	poolPrefixLength int
 }
 
 func New(c Config) (*PodCIDR, error) {
@@ -38,12 +47,29 @@ func New(c Config) (*PodCIDR, error) {
 		return nil, microerror.Maskf(invalidConfigError, "%T.InstallationCIDR must not be empty", c)
 	}
 
+	var pool *net.IPNet
+	if c.PoolCIDR != "" {
+		_, n, err := net.ParseCIDR(c.PoolCIDR)
+		if err != nil {
+			return nil, microerror.Maskf(invalidConfigError, "%T.PoolCIDR must be a valid CIDR: %s", c, err)
+		}
+
+		ones, bits := n.Mask.Size()
+		if c.PoolPrefixLength < ones || c.PoolPrefixLength > bits {
+			return nil, microerror.Maskf(invalidConfigError, "%T.PoolPrefixLength must be between %d and %d", c, ones, bits)
+		}
+
+		pool = n
+	}
+
 	p := &PodCIDR{
 		k8sClient: c.K8sClient,
 
 		clusterCache: cache.NewCluster(),
 
 		installationCIDR: c.InstallationCIDR,
+		pool:             pool,
+		poolPrefixLength: c.PoolPrefixLength,
 	}
 
 	return p, nil
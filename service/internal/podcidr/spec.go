@@ -5,6 +5,15 @@ import (
 )
 
 type Interface interface {
+	// Allocate assigns a pod CIDR from the installation pool to Tenant Clusters
+	// not having a pod CIDR configured in their AWSCluster CR. It returns the
+	// allocated pod CIDR, or an empty string if nothing was allocated.
+	Allocate(ctx context.Context, obj interface{}) (string, error)
+	// Overlaps returns a description of every pod CIDR the pod CIDR configured
+	// in the AWSCluster CR of the Tenant Cluster overlaps with. Tenant Clusters
+	// using the installation default share their pod CIDR by design and are
+	// not considered.
+	Overlaps(ctx context.Context, obj interface{}) ([]string, error)
 	// PodCIDR provides the pod CIDR to be used for Tenant Clusters depending on
 	// the installation and AWSCluster CR configuration. The CR value is prefered
 	// over the default value in the installation.
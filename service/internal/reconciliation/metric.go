@@ -0,0 +1,27 @@
+package reconciliation
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	resultError   = "error"
+	resultSuccess = "success"
+)
+
+var (
+	loopDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "cluster_operator",
+			Subsystem: "reconciliation",
+			Name:      "duration_seconds",
+			Help:      "Duration of reconciliation loops from the first to the last resource run.",
+			Buckets:   prometheus.ExponentialBuckets(0.5, 2, 12),
+		},
+		[]string{"controller", "result"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(loopDuration)
+}
@@ -0,0 +1,204 @@
+package reconciliation
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/giantswarm/microerror"
+)
+
+// Cluster is the reconciliation state of a tenant cluster in a controller.
+// Controllers reconciling several objects per tenant cluster, e.g. one
+// MachineDeployment per node pool, report the state of the object doing worst.
+type Cluster struct {
+	ClusterID  string
+	Controller string
+
+	// ConsecutiveErrors is the number of reconciliation loops which failed in a
+	// row.
+	ConsecutiveErrors int
+	// Duration is the duration of the latest reconciliation loop.
+	Duration time.Duration
+	// LastSuccess is the time the latest successful reconciliation loop
+	// finished. It is zero when no reconciliation loop succeeded yet.
+	LastSuccess time.Time
+}
+
+type TrackerConfig struct {
+	// MaxClusterIDs is the maximum number of tenant clusters the state is
+	// tracked for, which bounds the cardinality of the per cluster metrics.
+	// Tenant clusters beyond the limit are only counted. Tracking is disabled
+	// when 0.
+	MaxClusterIDs int
+}
+
+// Tracker tracks the reconciliation loops of the objects of tenant clusters
+// as observed by resources wrapped using Wrap.
+type Tracker struct {
+	maxClusterIDs int
+
+	mutex      sync.Mutex
+	clusterIDs map[string]int
+	loops      map[string]time.Time
+	objects    map[object]*state
+	untracked  map[string]bool
+}
+
+type object struct {
+	clusterID  string
+	controller string
+	name       string
+}
+
+type state struct {
+	consecutiveErrors int
+	duration          time.Duration
+	lastSuccess       time.Time
+}
+
+func NewTracker(config TrackerConfig) (*Tracker, error) {
+	if config.MaxClusterIDs < 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.MaxClusterIDs must not be negative", config)
+	}
+
+	t := &Tracker{
+		maxClusterIDs: config.MaxClusterIDs,
+
+		clusterIDs: map[string]int{},
+		loops:      map[string]time.Time{},
+		objects:    map[object]*state{},
+		untracked:  map[string]bool{},
+	}
+
+	return t, nil
+}
+
+// Clusters returns the reconciliation state of all tracked tenant clusters
+// ordered by controller and cluster ID.
+func (t *Tracker) Clusters() []Cluster {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	type group struct {
+		clusterID  string
+		controller string
+	}
+
+	clusters := map[group]*Cluster{}
+	for o, s := range t.objects {
+		g := group{clusterID: o.clusterID, controller: o.controller}
+
+		c, ok := clusters[g]
+		if !ok {
+			c = &Cluster{
+				ClusterID:   o.clusterID,
+				Controller:  o.controller,
+				LastSuccess: s.lastSuccess,
+			}
+			clusters[g] = c
+		}
+
+		if s.consecutiveErrors > c.ConsecutiveErrors {
+			c.ConsecutiveErrors = s.consecutiveErrors
+		}
+		if s.duration > c.Duration {
+			c.Duration = s.duration
+		}
+		if s.lastSuccess.Before(c.LastSuccess) {
+			c.LastSuccess = s.lastSuccess
+		}
+	}
+
+	var list []Cluster
+	for _, c := range clusters {
+		list = append(list, *c)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Controller != list[j].Controller {
+			return list[i].Controller < list[j].Controller
+		}
+		return list[i].ClusterID < list[j].ClusterID
+	})
+
+	return list
+}
+
+// Untracked returns the number of tenant clusters not tracked because of the
+// limit of tracked tenant clusters.
+func (t *Tracker) Untracked() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return len(t.untracked)
+}
+
+// finish records the end of the given reconciliation loop of the given
+// object. Objects which are gone after the loop are not tracked anymore.
+func (t *Tracker) finish(loop string, o object, failed bool, gone bool, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	start, started := t.loops[loop]
+	delete(t.loops, loop)
+
+	var duration time.Duration
+	if started {
+		duration = now.Sub(start)
+
+		result := resultSuccess
+		if failed {
+			result = resultError
+		}
+		loopDuration.WithLabelValues(o.controller, result).Observe(duration.Seconds())
+	}
+
+	if o.clusterID == "" {
+		return
+	}
+
+	if gone {
+		if _, ok := t.objects[o]; ok {
+			delete(t.objects, o)
+
+			t.clusterIDs[o.clusterID]--
+			if t.clusterIDs[o.clusterID] == 0 {
+				delete(t.clusterIDs, o.clusterID)
+			}
+		}
+		delete(t.untracked, o.clusterID)
+
+		return
+	}
+
+	s, ok := t.objects[o]
+	if !ok {
+		if t.clusterIDs[o.clusterID] == 0 && len(t.clusterIDs) >= t.maxClusterIDs {
+			t.untracked[o.clusterID] = true
+			return
+		}
+
+		s = &state{}
+		t.objects[o] = s
+		t.clusterIDs[o.clusterID]++
+	}
+
+	if started {
+		s.duration = duration
+	}
+	if failed {
+		s.consecutiveErrors++
+	} else {
+		s.consecutiveErrors = 0
+		s.lastSuccess = now
+	}
+}
+
+// start records the start of the given reconciliation loop.
+func (t *Tracker) start(loop string, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.loops[loop] = now
+}
@@ -0,0 +1,124 @@
+package reconciliation
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func Test_Tracker(t *testing.T) {
+	now := time.Date(2020, 12, 1, 12, 0, 0, 0, time.UTC)
+
+	type loop struct {
+		object   object
+		duration time.Duration
+		failed   bool
+		gone     bool
+	}
+
+	testCases := []struct {
+		name          string
+		maxClusterIDs int
+		loops         []loop
+
+		expectClusters  []Cluster
+		expectUntracked int
+	}{
+		{
+			name:          "case 0: successful loop",
+			maxClusterIDs: 10,
+			loops: []loop{
+				{object: object{clusterID: "al9qy", controller: "cluster", name: "default/al9qy"}, duration: time.Second},
+			},
+			expectClusters: []Cluster{
+				{ClusterID: "al9qy", Controller: "cluster", Duration: time.Second, LastSuccess: now.Add(time.Second)},
+			},
+		},
+		{
+			name:          "case 1: consecutive errors are reset by successful loops",
+			maxClusterIDs: 10,
+			loops: []loop{
+				{object: object{clusterID: "al9qy", controller: "cluster", name: "default/al9qy"}, duration: time.Second, failed: true},
+				{object: object{clusterID: "al9qy", controller: "cluster", name: "default/al9qy"}, duration: time.Second},
+				{object: object{clusterID: "al9qy", controller: "cluster", name: "default/al9qy"}, duration: 2 * time.Second, failed: true},
+				{object: object{clusterID: "al9qy", controller: "cluster", name: "default/al9qy"}, duration: 3 * time.Second, failed: true},
+			},
+			expectClusters: []Cluster{
+				{ClusterID: "al9qy", Controller: "cluster", ConsecutiveErrors: 2, Duration: 3 * time.Second, LastSuccess: now.Add(2 * time.Second)},
+			},
+		},
+		{
+			name:          "case 2: objects of a tenant cluster report the worst state",
+			maxClusterIDs: 10,
+			loops: []loop{
+				{object: object{clusterID: "al9qy", controller: "machinedeployment", name: "default/a1b2c"}, duration: time.Second},
+				{object: object{clusterID: "al9qy", controller: "machinedeployment", name: "default/d3e4f"}, duration: 5 * time.Second, failed: true},
+				{object: object{clusterID: "al9qy", controller: "machinedeployment", name: "default/a1b2c"}, duration: time.Second},
+			},
+			expectClusters: []Cluster{
+				{ClusterID: "al9qy", Controller: "machinedeployment", ConsecutiveErrors: 1, Duration: 5 * time.Second},
+			},
+		},
+		{
+			name:          "case 3: tenant clusters beyond the limit are counted",
+			maxClusterIDs: 1,
+			loops: []loop{
+				{object: object{clusterID: "al9qy", controller: "cluster", name: "default/al9qy"}, duration: time.Second},
+				{object: object{clusterID: "x7y8z", controller: "cluster", name: "default/x7y8z"}, duration: time.Second},
+				{object: object{clusterID: "al9qy", controller: "controlplane", name: "default/al9qy"}, duration: time.Second},
+			},
+			expectClusters: []Cluster{
+				{ClusterID: "al9qy", Controller: "cluster", Duration: time.Second, LastSuccess: now.Add(time.Second)},
+				{ClusterID: "al9qy", Controller: "controlplane", Duration: time.Second, LastSuccess: now.Add(3 * time.Second)},
+			},
+			expectUntracked: 1,
+		},
+		{
+			name:          "case 4: tracking disabled",
+			maxClusterIDs: 0,
+			loops: []loop{
+				{object: object{clusterID: "al9qy", controller: "cluster", name: "default/al9qy"}, duration: time.Second},
+			},
+			expectClusters:  nil,
+			expectUntracked: 1,
+		},
+		{
+			name:          "case 5: deleted objects are not tracked anymore",
+			maxClusterIDs: 1,
+			loops: []loop{
+				{object: object{clusterID: "al9qy", controller: "cluster", name: "default/al9qy"}, duration: time.Second},
+				{object: object{clusterID: "al9qy", controller: "cluster", name: "default/al9qy"}, duration: time.Second, gone: true},
+				{object: object{clusterID: "x7y8z", controller: "cluster", name: "default/x7y8z"}, duration: time.Second},
+			},
+			expectClusters: []Cluster{
+				{ClusterID: "x7y8z", Controller: "cluster", Duration: time.Second, LastSuccess: now.Add(3 * time.Second)},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			tracker, err := NewTracker(TrackerConfig{MaxClusterIDs: tc.maxClusterIDs})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Every loop starts where the previous one finished.
+			start := now
+			for j, l := range tc.loops {
+				tracker.start(strconv.Itoa(j), start)
+				tracker.finish(strconv.Itoa(j), l.object, l.failed, l.gone, start.Add(l.duration))
+				start = start.Add(l.duration)
+			}
+
+			clusters := tracker.Clusters()
+			if !reflect.DeepEqual(clusters, tc.expectClusters) {
+				t.Fatalf("clusters == %#v, want %#v", clusters, tc.expectClusters)
+			}
+			if tracker.Untracked() != tc.expectUntracked {
+				t.Fatalf("untracked == %d, want %d", tracker.Untracked(), tc.expectUntracked)
+			}
+		})
+	}
+}
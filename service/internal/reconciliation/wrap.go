@@ -0,0 +1,127 @@
+package reconciliation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/cachekeycontext"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/finalizerskeptcontext"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/reconciliationcanceledcontext"
+	"github.com/giantswarm/operatorkit/v4/pkg/resource"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+type WrapConfig struct {
+	Tracker *Tracker
+
+	// Controller is the name of the controller the resources belong to, e.g.
+	// cluster.
+	Controller string
+}
+
+// Wrap wraps the given resources so that the reconciliation loops running
+// them are recorded in the Tracker. A loop starts with the first resource and
+// ends with the first resource failing, the first resource canceling the
+// reconciliation or the last resource.
+func Wrap(resources []resource.Interface, config WrapConfig) ([]resource.Interface, error) {
+	if config.Tracker == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Tracker must not be empty", config)
+	}
+
+	if config.Controller == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Controller must not be empty", config)
+	}
+
+	var wrapped []resource.Interface
+	for i, r := range resources {
+		w := &wrapper{
+			resource: r,
+			tracker:  config.Tracker,
+
+			controller: config.Controller,
+			first:      i == 0,
+			last:       i == len(resources)-1,
+		}
+
+		wrapped = append(wrapped, w)
+	}
+
+	return wrapped, nil
+}
+
+type wrapper struct {
+	resource resource.Interface
+	tracker  *Tracker
+
+	controller string
+	first      bool
+	last       bool
+}
+
+func (w *wrapper) EnsureCreated(ctx context.Context, obj interface{}) error {
+	err := w.ensure(ctx, obj, false, w.resource.EnsureCreated)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (w *wrapper) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	err := w.ensure(ctx, obj, true, w.resource.EnsureDeleted)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (w *wrapper) Name() string {
+	return w.resource.Name()
+}
+
+func (w *wrapper) ensure(ctx context.Context, obj interface{}, deleting bool, f func(context.Context, interface{}) error) error {
+	// Loops are identified by the cache key operatorkit computes for every
+	// reconciliation loop. Without it there is nothing to track.
+	loop, ok := cachekeycontext.FromContext(ctx)
+	if !ok {
+		return f(ctx, obj)
+	}
+
+	if w.first {
+		w.tracker.start(loop, time.Now())
+	}
+
+	err := f(ctx, obj)
+	if err != nil {
+		w.finish(loop, obj, true, false)
+		return microerror.Mask(err)
+	}
+
+	if w.last || reconciliationcanceledcontext.IsCanceled(ctx) {
+		// Objects being deleted are gone once their finalizers are removed
+		// after the loop, which happens unless a resource keeps them.
+		gone := deleting && !reconciliationcanceledcontext.IsCanceled(ctx) && !finalizerskeptcontext.IsKept(ctx)
+		w.finish(loop, obj, false, gone)
+	}
+
+	return nil
+}
+
+func (w *wrapper) finish(loop string, obj interface{}, failed bool, gone bool) {
+	o := object{
+		controller: w.controller,
+	}
+
+	cr, err := meta.Accessor(obj)
+	if err == nil {
+		o.clusterID = key.ClusterID(cr)
+		o.name = fmt.Sprintf("%s/%s", cr.GetNamespace(), cr.GetName())
+	}
+
+	w.tracker.finish(loop, o, failed, gone, time.Now())
+}
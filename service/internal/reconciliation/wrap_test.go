@@ -0,0 +1,159 @@
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/cachekeycontext"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/finalizerskeptcontext"
+	"github.com/giantswarm/operatorkit/v4/pkg/controller/context/reconciliationcanceledcontext"
+	"github.com/giantswarm/operatorkit/v4/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+)
+
+type testResource struct {
+	cancel bool
+	err    error
+	keep   bool
+	runs   int
+}
+
+func (r *testResource) EnsureCreated(ctx context.Context, obj interface{}) error {
+	return r.ensure(ctx)
+}
+
+func (r *testResource) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	return r.ensure(ctx)
+}
+
+func (r *testResource) Name() string {
+	return "test"
+}
+
+func (r *testResource) ensure(ctx context.Context) error {
+	r.runs++
+	if r.cancel {
+		reconciliationcanceledcontext.SetCanceled(ctx)
+	}
+	if r.keep {
+		finalizerskeptcontext.SetKept(ctx)
+	}
+
+	return r.err
+}
+
+func Test_Wrap(t *testing.T) {
+	testCases := []struct {
+		name      string
+		resources []*testResource
+		deleting  bool
+
+		expectClusters          int
+		expectConsecutiveErrors int
+		expectSuccess           bool
+	}{
+		{
+			name:      "case 0: all resources run",
+			resources: []*testResource{{}, {}},
+
+			expectClusters: 1,
+			expectSuccess:  true,
+		},
+		{
+			name:      "case 1: failing resource ends the loop",
+			resources: []*testResource{{err: errors.New("test")}, {}},
+
+			expectClusters:          1,
+			expectConsecutiveErrors: 1,
+		},
+		{
+			name:      "case 2: canceled reconciliation ends the loop",
+			resources: []*testResource{{cancel: true}, {}},
+
+			expectClusters: 1,
+			expectSuccess:  true,
+		},
+		{
+			name:      "case 3: deleted object",
+			resources: []*testResource{{}, {}},
+			deleting:  true,
+
+			expectClusters: 0,
+		},
+		{
+			name:      "case 4: object being deleted keeping finalizers",
+			resources: []*testResource{{keep: true}, {}},
+			deleting:  true,
+
+			expectClusters: 1,
+			expectSuccess:  true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			tracker, err := NewTracker(TrackerConfig{MaxClusterIDs: 10})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var resources []resource.Interface
+			for _, r := range tc.resources {
+				resources = append(resources, r)
+			}
+
+			resources, err = Wrap(resources, WrapConfig{Tracker: tracker, Controller: "cluster"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			obj := &apiv1alpha2.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						label.Cluster: "al9qy",
+					},
+					Name:      "al9qy",
+					Namespace: "default",
+				},
+			}
+
+			// The loop is run the way operatorkit runs it.
+			ctx := cachekeycontext.NewContext(context.Background(), "cluster-1")
+			ctx = finalizerskeptcontext.NewContext(ctx, make(chan struct{}))
+			ctx = reconciliationcanceledcontext.NewContext(ctx, make(chan struct{}))
+			for _, r := range resources {
+				if tc.deleting {
+					err = r.EnsureDeleted(ctx, obj)
+				} else {
+					err = r.EnsureCreated(ctx, obj)
+				}
+				if err != nil || reconciliationcanceledcontext.IsCanceled(ctx) {
+					break
+				}
+			}
+
+			clusters := tracker.Clusters()
+			if len(clusters) != tc.expectClusters {
+				t.Fatalf("clusters == %d, want %d", len(clusters), tc.expectClusters)
+			}
+			if tc.expectClusters == 0 {
+				return
+			}
+
+			if clusters[0].ConsecutiveErrors != tc.expectConsecutiveErrors {
+				t.Fatalf("consecutive errors == %d, want %d", clusters[0].ConsecutiveErrors, tc.expectConsecutiveErrors)
+			}
+			if clusters[0].LastSuccess.IsZero() == tc.expectSuccess {
+				t.Fatalf("last success == %s, want success %t", clusters[0].LastSuccess, tc.expectSuccess)
+			}
+			if len(tracker.loops) != 0 {
+				t.Fatalf("loops == %d, want 0", len(tracker.loops))
+			}
+		})
+	}
+}
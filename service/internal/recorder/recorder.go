@@ -44,6 +44,12 @@ func (r *Recorder) Emit(ctx context.Context, obj pkgruntime.Object, reason, mess
 	r.Event(obj, corev1.EventTypeNormal, reason, upper(message))
 }
 
+// Warn writes warning events about conditions requiring attention, which do
+// not fail the reconciliation and are thus not handled by operatorkit.
+func (r *Recorder) Warn(ctx context.Context, obj pkgruntime.Object, reason, message string) {
+	r.Event(obj, corev1.EventTypeWarning, reason, upper(message))
+}
+
 // upper is a helper function to uppercase first letter of the event message
 func upper(in string) string {
 	out := []rune(in)
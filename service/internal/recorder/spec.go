@@ -9,4 +9,7 @@ import (
 type Interface interface {
 	// Emit is used to create Kubernetes events.
 	Emit(ctx context.Context, obj pkgruntime.Object, reason, message string)
+	// Warn is used to create Kubernetes warning events about conditions
+	// requiring attention, which do not fail the reconciliation.
+	Warn(ctx context.Context, obj pkgruntime.Object, reason, message string)
 }
@@ -0,0 +1,162 @@
+package releasetrigger
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	releasev1alpha1 "github.com/giantswarm/apiextensions/v3/pkg/apis/release/v1alpha1"
+	"github.com/giantswarm/k8sclient/v5/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	apiv1alpha2 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/cluster-operator/v3/pkg/annotation"
+	"github.com/giantswarm/cluster-operator/v3/pkg/label"
+	"github.com/giantswarm/cluster-operator/v3/pkg/project"
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+)
+
+const (
+	// resyncPeriod is the interval in which all Release CRs are handled again,
+	// which retries failed triggers.
+	resyncPeriod = 5 * time.Minute
+)
+
+type Config struct {
+	K8sClient k8sclient.Interface
+	Logger    micrologger.Logger
+}
+
+// Watcher watches the Release CRs tenant clusters are running. The Cluster CR
+// is not reconciled when its Release CR changes. The watcher propagates the
+// resource version of the Release CR to the following annotation of the
+// Cluster CR, which ensures e.g. the cluster values of the tenant cluster
+// reflect the Release CR right away.
+//
+//     cluster-operator.giantswarm.io/release-resource-version
+//
+type Watcher struct {
+	k8sClient k8sclient.Interface
+	logger    micrologger.Logger
+}
+
+func New(config Config) (*Watcher, error) {
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	w := &Watcher{
+		k8sClient: config.K8sClient,
+		logger:    config.Logger,
+	}
+
+	return w, nil
+}
+
+// Boot watches the Release CRs until the given context is done. Deleted
+// Release CRs are not handled, because tenant clusters cannot be reconciled
+// without their Release CR anyway.
+func (w *Watcher) Boot(ctx context.Context) {
+	releases := w.k8sClient.G8sClient().ReleaseV1alpha1().Releases()
+
+	informer := cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(o metav1.ListOptions) (runtime.Object, error) {
+				return releases.List(ctx, o)
+			},
+			WatchFunc: func(o metav1.ListOptions) (watch.Interface, error) {
+				return releases.Watch(ctx, o)
+			},
+		},
+		&releasev1alpha1.Release{},
+		resyncPeriod,
+		cache.Indexers{},
+	)
+
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			w.handle(ctx, obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			w.handle(ctx, newObj)
+		},
+	})
+
+	informer.Run(ctx.Done())
+}
+
+func (w *Watcher) handle(ctx context.Context, obj interface{}) {
+	release, ok := obj.(*releasev1alpha1.Release)
+	if !ok {
+		return
+	}
+
+	// Release CRs are named after the release version prefixed with "v", see
+	// key.ReleaseName.
+	releaseVersion := strings.TrimPrefix(release.GetName(), "v")
+
+	err := w.trigger(ctx, releaseVersion, release.GetResourceVersion())
+	if err != nil {
+		w.logger.Errorf(ctx, err, "failed to propagate Release CR %#q", release.GetName())
+	}
+}
+
+// trigger annotates the Cluster CRs of the given release version reconciled by
+// this operator version with the given Release CR resource version.
+func (w *Watcher) trigger(ctx context.Context, releaseVersion string, version string) error {
+	var list apiv1alpha2.ClusterList
+	{
+		err := w.k8sClient.CtrlClient().List(
+			ctx,
+			&list,
+			client.MatchingLabels{
+				label.OperatorVersion: project.Version(),
+				label.ReleaseVersion:  releaseVersion,
+			},
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	for _, cl := range list.Items {
+		cl := cl // dereferencing pointer value into new scope
+
+		if key.IsDeleted(&cl) {
+			continue
+		}
+
+		if cl.GetAnnotations()[annotation.ReleaseResourceVersion] == version {
+			continue
+		}
+
+		w.logger.Debugf(ctx, "updating annotation %#q of Cluster CR for tenant cluster %#q", annotation.ReleaseResourceVersion, key.ClusterID(&cl))
+
+		patch := client.MergeFrom(cl.DeepCopy())
+
+		a := cl.GetAnnotations()
+		if a == nil {
+			a = map[string]string{}
+		}
+		a[annotation.ReleaseResourceVersion] = version
+		cl.SetAnnotations(a)
+
+		err := w.k8sClient.CtrlClient().Patch(ctx, &cl, patch)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		w.logger.Debugf(ctx, "updated annotation %#q of Cluster CR for tenant cluster %#q", annotation.ReleaseResourceVersion, key.ClusterID(&cl))
+	}
+
+	return nil
+}
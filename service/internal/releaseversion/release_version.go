@@ -75,6 +75,20 @@ func (rv *ReleaseVersion) ComponentVersion(ctx context.Context, obj interface{})
 	return components, nil
 }
 
+func (rv *ReleaseVersion) Release(ctx context.Context, obj interface{}) (releasev1alpha1.Release, error) {
+	cr, err := meta.Accessor(obj)
+	if err != nil {
+		return releasev1alpha1.Release{}, microerror.Mask(err)
+	}
+
+	release, err := rv.cachedRelease(ctx, cr)
+	if err != nil {
+		return releasev1alpha1.Release{}, microerror.Mask(err)
+	}
+
+	return release, nil
+}
+
 func (rv *ReleaseVersion) cachedRelease(ctx context.Context, cr metav1.Object) (releasev1alpha1.Release, error) {
 	var err error
 	var ok bool
@@ -2,6 +2,8 @@ package releaseversion
 
 import (
 	"context"
+
+	releasev1alpha1 "github.com/giantswarm/apiextensions/v3/pkg/apis/release/v1alpha1"
 )
 
 const (
@@ -16,6 +18,9 @@ type Interface interface {
 	Apps(ctx context.Context, obj interface{}) (map[string]ReleaseApp, error)
 	// ComponentVersion provides the version of each component in a release.
 	ComponentVersion(ctx context.Context, obj interface{}) (map[string]string, error)
+	// Release provides the Release CR of the release the given object refers
+	// to.
+	Release(ctx context.Context, obj interface{}) (releasev1alpha1.Release, error)
 }
 
 type ReleaseApp struct {
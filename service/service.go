@@ -3,10 +3,12 @@ package service
 import (
 	"context"
 	"fmt"
-	"net"
+	"regexp"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/ghodss/yaml"
 	infrastructurev1alpha2 "github.com/giantswarm/apiextensions/v3/pkg/apis/infrastructure/v1alpha2"
 	releasev1alpha1 "github.com/giantswarm/apiextensions/v3/pkg/apis/release/v1alpha1"
 	"github.com/giantswarm/certs/v3/pkg/certs"
@@ -26,16 +28,22 @@ import (
 	"github.com/giantswarm/cluster-operator/v3/service/collector"
 	"github.com/giantswarm/cluster-operator/v3/service/controller"
 	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
+	"github.com/giantswarm/cluster-operator/v3/service/deletionpreview"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/basedomain"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/clusterip"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/ingressvalues"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/nodecount"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/orphan"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/podcidr"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/reconciliation"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/recorder"
+	"github.com/giantswarm/cluster-operator/v3/service/internal/releasetrigger"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/releaseversion"
 	"github.com/giantswarm/cluster-operator/v3/service/internal/tenantclient"
 )
 
-const (
-	apiServerIPLastOctet = 1
+var (
+	kubeConfigProfileNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
 )
 
 // Config represents the configuration used to create a new service.
@@ -54,13 +62,17 @@ type Config struct {
 
 // Service is a type providing implementation of microkit service interface.
 type Service struct {
-	Version *version.Service
+	DeletionPreview deletionpreview.Interface
+	Version         *version.Service
 
 	bootOnce                    sync.Once
 	clusterController           *controller.Cluster
 	controlPlaneController      *controller.ControlPlane
+	ingressValuesWatcher        *ingressvalues.Watcher
 	machineDeploymentController *controller.MachineDeployment
 	operatorCollector           *collector.Set
+	orphanSweeper               *orphan.Sweeper
+	releaseWatcher              *releasetrigger.Watcher
 }
 
 // New creates a new service with given configuration.
@@ -74,9 +86,6 @@ func New(config Config) (*Service, error) {
 
 	var err error
 
-	calicoSubnet := config.Viper.GetString(config.Flag.Guest.Cluster.Calico.Subnet)
-	calicoCIDR := config.Viper.GetString(config.Flag.Guest.Cluster.Calico.CIDR)
-	clusterIPRange := config.Viper.GetString(config.Flag.Guest.Cluster.Kubernetes.API.ClusterIPRange)
 	provider := config.Viper.GetString(config.Flag.Service.Provider.Kind)
 	registryDomain := config.Viper.GetString(config.Flag.Service.Image.Registry.Domain)
 
@@ -120,21 +129,39 @@ func New(config Config) (*Service, error) {
 		}
 	}
 
-	var dnsIP string
+	var calicoCIDR string
 	{
-		dnsIP, err = key.DNSIP(clusterIPRange)
+		calicoCIDR, err = parseCalicoCIDR(
+			config.Viper.GetString(config.Flag.Guest.Cluster.Calico.Subnet),
+			config.Viper.GetString(config.Flag.Guest.Cluster.Calico.CIDR),
+		)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
 	}
 
-	var apiIP string
+	var kubeConfigProfiles []key.KubeConfigProfile
 	{
-		_, ip, err := parseClusterIPRange(config.Viper.GetString(config.Flag.Guest.Cluster.Kubernetes.API.ClusterIPRange))
+		kubeConfigProfiles, err = parseKubeConfigProfiles(config.Viper.GetString(config.Flag.Service.KubeConfig.Profiles))
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var healthProbes []key.HealthProbe
+	{
+		healthProbes, err = parseHealthProbes(config.Viper.GetString(config.Flag.Service.ComponentHealth.Probes))
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var deletionPhaseTimeouts map[string]time.Duration
+	{
+		deletionPhaseTimeouts, err = parseDeletionPhaseTimeouts(config.Viper.GetString(config.Flag.Service.Deletion.PhaseTimeouts))
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
-		apiIP = ip.String()
 	}
 
 	var certsSearcher certs.Interface
@@ -172,7 +199,9 @@ func New(config Config) (*Service, error) {
 		c := podcidr.Config{
 			K8sClient: k8sClient,
 
-			InstallationCIDR: fmt.Sprintf("%s/%s", calicoSubnet, calicoCIDR),
+			InstallationCIDR: calicoCIDR,
+			PoolCIDR:         config.Viper.GetString(config.Flag.Guest.Cluster.Calico.Pool.CIDR),
+			PoolPrefixLength: config.Viper.GetInt(config.Flag.Guest.Cluster.Calico.Pool.PrefixLength),
 		}
 
 		pc, err = podcidr.New(c)
@@ -181,6 +210,20 @@ func New(config Config) (*Service, error) {
 		}
 	}
 
+	var ci clusterip.Interface
+	{
+		c := clusterip.Config{
+			K8sClient: k8sClient,
+
+			InstallationClusterIPRange: config.Viper.GetString(config.Flag.Guest.Cluster.Kubernetes.API.ClusterIPRange),
+		}
+
+		ci, err = clusterip.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var bd basedomain.Interface
 	{
 		c := basedomain.Config{
@@ -244,29 +287,53 @@ func New(config Config) (*Service, error) {
 		eventRecorder = recorder.New(c)
 	}
 
+	var reconciliationTracker *reconciliation.Tracker
+	{
+		c := reconciliation.TrackerConfig{
+			MaxClusterIDs: config.Viper.GetInt(config.Flag.Service.Metrics.MaxClusterIDs),
+		}
+
+		reconciliationTracker, err = reconciliation.NewTracker(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var clusterController *controller.Cluster
 	{
 		c := controller.ClusterConfig{
-			BaseDomain:     bd,
-			CertsSearcher:  certsSearcher,
-			Event:          eventRecorder,
-			FileSystem:     afero.NewOsFs(),
-			K8sClient:      k8sClient,
-			Logger:         config.Logger,
-			PodCIDR:        pc,
-			Tenant:         tenantCluster,
-			ReleaseVersion: rv,
-
-			APIIP:                      apiIP,
-			CertTTL:                    config.Viper.GetString(config.Flag.Guest.Cluster.Vault.Certificate.TTL),
-			ClusterIPRange:             clusterIPRange,
-			DNSIP:                      dnsIP,
-			ClusterDomain:              config.Viper.GetString(config.Flag.Guest.Cluster.Kubernetes.ClusterDomain),
-			NewCommonClusterObjectFunc: newCommonClusterObjectFunc(provider),
-			Provider:                   provider,
-			RawAppDefaultConfig:        config.Viper.GetString(config.Flag.Service.Release.App.Config.Default),
-			RawAppOverrideConfig:       config.Viper.GetString(config.Flag.Service.Release.App.Config.Override),
-			RegistryDomain:             registryDomain,
+			BaseDomain:            bd,
+			CertsSearcher:         certsSearcher,
+			ClusterIP:             ci,
+			Event:                 eventRecorder,
+			FileSystem:            afero.NewOsFs(),
+			K8sClient:             k8sClient,
+			Logger:                config.Logger,
+			PodCIDR:               pc,
+			Tenant:                tenantCluster,
+			ReconciliationTracker: reconciliationTracker,
+			ReleaseVersion:        rv,
+
+			CertTTL:                     config.Viper.GetString(config.Flag.Guest.Cluster.Vault.Certificate.TTL),
+			ClusterDomain:               config.Viper.GetString(config.Flag.Guest.Cluster.Kubernetes.ClusterDomain),
+			ClusterValuesTemplate:       config.Viper.GetString(config.Flag.Service.ClusterValues.Template),
+			DegradedCreationThreshold:   config.Viper.GetDuration(config.Flag.Service.Degraded.CreationThreshold),
+			DegradedUpdateThreshold:     config.Viper.GetDuration(config.Flag.Service.Degraded.UpdateThreshold),
+			DeletionBlockerMaxAge:       config.Viper.GetDuration(config.Flag.Service.Deletion.BlockerMaxAge),
+			DeletionPhaseTimeouts:       deletionPhaseTimeouts,
+			HealthProbes:                healthProbes,
+			IngressProviderDefaults:     config.Viper.GetBool(config.Flag.Service.IngressController.ProviderDefaults),
+			KubeConfigCAPISecret:        config.Viper.GetBool(config.Flag.Service.KubeConfig.Secret.CAPI),
+			KubeConfigProfiles:          kubeConfigProfiles,
+			NewCommonClusterObjectFunc:  newCommonClusterObjectFunc(provider),
+			Provider:                    provider,
+			RawAppDefaultConfig:         config.Viper.GetString(config.Flag.Service.Release.App.Config.Default),
+			RawAppOverrideConfig:        config.Viper.GetString(config.Flag.Service.Release.App.Config.Override),
+			RegistryDomain:              registryDomain,
+			RegistryMirrors:             parseRegistryMirrors(config.Viper.GetString(config.Flag.Service.Image.Registry.Mirrors)),
+			RegistryPullSecretName:      config.Viper.GetString(config.Flag.Service.Image.Registry.PullSecret.Name),
+			RegistryPullSecretNamespace: config.Viper.GetString(config.Flag.Service.Image.Registry.PullSecret.Namespace),
+			TenantCleanupTimeout:        config.Viper.GetDuration(config.Flag.Service.Deletion.TenantCleanupTimeout),
 		}
 
 		clusterController, err = controller.NewCluster(c)
@@ -278,13 +345,14 @@ func New(config Config) (*Service, error) {
 	var controlPlaneController *controller.ControlPlane
 	{
 		c := controller.ControlPlaneConfig{
-			BaseDomain:     bd,
-			Event:          eventRecorder,
-			K8sClient:      k8sClient,
-			Logger:         config.Logger,
-			NodeCount:      nc,
-			Tenant:         tenantCluster,
-			ReleaseVersion: rv,
+			BaseDomain:            bd,
+			Event:                 eventRecorder,
+			K8sClient:             k8sClient,
+			Logger:                config.Logger,
+			NodeCount:             nc,
+			Tenant:                tenantCluster,
+			ReconciliationTracker: reconciliationTracker,
+			ReleaseVersion:        rv,
 
 			Provider: provider,
 		}
@@ -298,13 +366,14 @@ func New(config Config) (*Service, error) {
 	var machineDeploymentController *controller.MachineDeployment
 	{
 		c := controller.MachineDeploymentConfig{
-			BaseDomain:     bd,
-			Event:          eventRecorder,
-			K8sClient:      k8sClient,
-			Logger:         config.Logger,
-			NodeCount:      nc,
-			Tenant:         tenantCluster,
-			ReleaseVersion: rv,
+			BaseDomain:            bd,
+			Event:                 eventRecorder,
+			K8sClient:             k8sClient,
+			Logger:                config.Logger,
+			NodeCount:             nc,
+			Tenant:                tenantCluster,
+			ReconciliationTracker: reconciliationTracker,
+			ReleaseVersion:        rv,
 
 			Provider: provider,
 		}
@@ -315,13 +384,72 @@ func New(config Config) (*Service, error) {
 		}
 	}
 
+	var ingressValuesWatcher *ingressvalues.Watcher
+	{
+		c := ingressvalues.Config{
+			K8sClient: k8sClient,
+			Logger:    config.Logger,
+		}
+
+		ingressValuesWatcher, err = ingressvalues.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var releaseWatcher *releasetrigger.Watcher
+	{
+		c := releasetrigger.Config{
+			K8sClient: k8sClient,
+			Logger:    config.Logger,
+		}
+
+		releaseWatcher, err = releasetrigger.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var orphanService orphan.Interface
+	{
+		c := orphan.Config{
+			K8sClient: k8sClient,
+		}
+
+		orphanService, err = orphan.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var orphanSweeper *orphan.Sweeper
+	{
+		c := orphan.SweeperConfig{
+			Logger: config.Logger,
+			Orphan: orphanService,
+
+			Enforcing:   config.Viper.GetBool(config.Flag.Service.Orphan.Enforcing),
+			GracePeriod: config.Viper.GetDuration(config.Flag.Service.Orphan.GracePeriod),
+			Interval:    config.Viper.GetDuration(config.Flag.Service.Orphan.Interval),
+		}
+
+		orphanSweeper, err = orphan.NewSweeper(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var operatorCollector *collector.Set
 	{
 		c := collector.SetConfig{
-			CertSearcher: certsSearcher,
-			K8sClient:    k8sClient,
-			Logger:       config.Logger,
-
+			CertSearcher:          certsSearcher,
+			K8sClient:             k8sClient,
+			Logger:                config.Logger,
+			OrphanSweeper:         orphanSweeper,
+			ReconciliationTracker: reconciliationTracker,
+
+			DegradedCreationThreshold:  config.Viper.GetDuration(config.Flag.Service.Degraded.CreationThreshold),
+			DegradedUpdateThreshold:    config.Viper.GetDuration(config.Flag.Service.Degraded.UpdateThreshold),
 			NewCommonClusterObjectFunc: newCommonClusterObjectFunc(provider),
 		}
 
@@ -348,13 +476,17 @@ func New(config Config) (*Service, error) {
 	}
 
 	s := &Service{
-		Version: versionService,
+		DeletionPreview: clusterController,
+		Version:         versionService,
 
 		bootOnce:                    sync.Once{},
 		clusterController:           clusterController,
 		controlPlaneController:      controlPlaneController,
+		ingressValuesWatcher:        ingressValuesWatcher,
 		machineDeploymentController: machineDeploymentController,
 		operatorCollector:           operatorCollector,
+		orphanSweeper:               orphanSweeper,
+		releaseWatcher:              releaseWatcher,
 	}
 
 	return s, nil
@@ -374,6 +506,10 @@ func (s *Service) Boot(ctx context.Context) {
 		go s.clusterController.Boot(ctx)
 		go s.controlPlaneController.Boot(ctx)
 		go s.machineDeploymentController.Boot(ctx)
+
+		go s.ingressValuesWatcher.Boot(ctx)
+		go s.orphanSweeper.Boot(ctx)
+		go s.releaseWatcher.Boot(ctx)
 	})
 }
 
@@ -384,27 +520,132 @@ func newCommonClusterObjectFunc(provider string) func() infrastructurev1alpha2.C
 	}
 }
 
-func parseClusterIPRange(ipRange string) (net.IP, net.IP, error) {
-	_, cidr, err := net.ParseCIDR(ipRange)
-	if cidr == nil {
-		return nil, nil, microerror.Maskf(invalidConfigError, "invalid Kubernetes ClusterIPRange '%s': cidr == nil", ipRange)
-	} else if err != nil {
-		return nil, nil, microerror.Maskf(invalidConfigError, "invalid Kubernetes ClusterIPRange '%s': %q", ipRange, err)
+// parseCalicoCIDR returns the CIDR used by Calico given the comma separated
+// network addresses and prefix lengths of all IP families the cluster uses.
+// The result is a comma separated list of CIDRs as well.
+func parseCalicoCIDR(subnet string, prefix string) (string, error) {
+	subnets := strings.Split(subnet, ",")
+	prefixes := strings.Split(prefix, ",")
+	if len(subnets) != len(prefixes) {
+		return "", microerror.Maskf(invalidConfigError, "Calico subnet %#q and CIDR %#q must list the same number of IP families", subnet, prefix)
+	}
+
+	var cidrs []string
+	for i := range subnets {
+		cidrs = append(cidrs, fmt.Sprintf("%s/%s", strings.TrimSpace(subnets[i]), strings.TrimSpace(prefixes[i])))
 	}
 
-	ones, bits := cidr.Mask.Size()
-	if bits != 32 {
-		return nil, nil, microerror.Maskf(invalidConfigError, "Kubernetes ClusterIPRange CIDR must be an IPv4 range")
+	err := key.ValidateDualStack(cidrs)
+	if err != nil {
+		return "", microerror.Maskf(invalidConfigError, "invalid Calico CIDR: %s", err)
+	}
+
+	return strings.Join(cidrs, ","), nil
+}
+
+// parseDeletionPhaseTimeouts parses the given YAML map of tenant cluster
+// deletion phases to their timeouts, e.g. NodePools: 1h.
+func parseDeletionPhaseTimeouts(raw string) (map[string]time.Duration, error) {
+	timeouts := map[string]time.Duration{}
+	if raw == "" {
+		return timeouts, nil
 	}
 
-	// Node gets /24 from Kubernetes and each POD receives one IP from this
-	// block. Therefore CIDR block must be at least /24.
-	if ones > 24 {
-		return nil, nil, microerror.Maskf(invalidConfigError, "Kubernetes ClusterIPRange CIDR network block must be at least /24")
+	var m map[string]string
+	err := yaml.Unmarshal([]byte(raw), &m)
+	if err != nil {
+		return nil, microerror.Maskf(invalidConfigError, "invalid deletion phase timeouts: %q", err)
 	}
 
-	networkIP := cidr.IP.To4()
-	apiServerIP := net.IPv4(networkIP[0], networkIP[1], networkIP[2], apiServerIPLastOctet)
+	for p, v := range m {
+		if key.DeletionPhaseIndex(p) < 0 {
+			return nil, microerror.Maskf(invalidConfigError, "deletion phase %#q must be one of %#q", p, key.DeletionPhases)
+		}
+
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, microerror.Maskf(invalidConfigError, "invalid timeout of deletion phase %#q: %q", p, err)
+		}
+		if d <= 0 {
+			return nil, microerror.Maskf(invalidConfigError, "timeout of deletion phase %#q must be positive", p)
+		}
+
+		timeouts[p] = d
+	}
+
+	return timeouts, nil
+}
+
+func parseKubeConfigProfiles(raw string) ([]key.KubeConfigProfile, error) {
+	var profiles []key.KubeConfigProfile
+	if raw == "" {
+		return profiles, nil
+	}
+
+	err := yaml.Unmarshal([]byte(raw), &profiles)
+	if err != nil {
+		return nil, microerror.Maskf(invalidConfigError, "invalid kubeconfig profiles: %q", err)
+	}
+
+	names := map[string]bool{}
+	for _, p := range profiles {
+		if !kubeConfigProfileNameRegex.MatchString(p.Name) {
+			return nil, microerror.Maskf(invalidConfigError, "kubeconfig profile name %#q must be a lower case DNS label", p.Name)
+		}
+		if names[p.Name] {
+			return nil, microerror.Maskf(invalidConfigError, "kubeconfig profile name %#q must be unique", p.Name)
+		}
+		if p.ClusterRole == "" && len(p.Namespaces) != 0 {
+			return nil, microerror.Maskf(invalidConfigError, "kubeconfig profile %#q must define a cluster role when namespaces are given", p.Name)
+		}
+
+		names[p.Name] = true
+	}
+
+	return profiles, nil
+}
+
+// parseHealthProbes parses the given YAML list of health probes run against
+// tenant cluster components.
+func parseHealthProbes(raw string) ([]key.HealthProbe, error) {
+	var probes []key.HealthProbe
+	if raw == "" {
+		return probes, nil
+	}
+
+	err := yaml.Unmarshal([]byte(raw), &probes)
+	if err != nil {
+		return nil, microerror.Maskf(invalidConfigError, "invalid health probes: %q", err)
+	}
+
+	for _, p := range probes {
+		switch p.Kind {
+		case key.HealthProbeKindDaemonSet, key.HealthProbeKindDeployment:
+			if p.Name == "" {
+				return nil, microerror.Maskf(invalidConfigError, "%s health probe must define a name", p.Kind)
+			}
+		case key.HealthProbeKindReadyz:
+		default:
+			return nil, microerror.Maskf(invalidConfigError, "health probe kind %#q must be one of %#q, %#q or %#q", p.Kind, key.HealthProbeKindDaemonSet, key.HealthProbeKindDeployment, key.HealthProbeKindReadyz)
+		}
+	}
+
+	return probes, nil
+}
+
+// parseRegistryMirrors parses the given comma separated list of image registry
+// mirrors. The returned list is never nil so that it renders as an empty list
+// in the cluster values.
+func parseRegistryMirrors(raw string) []string {
+	mirrors := []string{}
+	for _, m := range strings.Split(raw, ",") {
+		m = strings.TrimSpace(m)
+		if m == "" {
+			continue
+		}
+
+		mirrors = append(mirrors, m)
+	}
 
-	return networkIP, apiServerIP, nil
+	return mirrors
 }
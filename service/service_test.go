@@ -1,80 +1,171 @@
 package service
 
 import (
-	"net"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/giantswarm/cluster-operator/v3/service/controller/key"
 )
 
-func Test_parseClusterIPRange(t *testing.T) {
+func Test_parseCalicoCIDR(t *testing.T) {
 	testCases := []struct {
-		name                string
-		inputCIDR           string
-		expectedNetworkIP   net.IP
-		expectedAPIServerIP net.IP
-		errorMatcher        func(error) bool
+		name         string
+		inputSubnet  string
+		inputPrefix  string
+		expectedCIDR string
+		errorMatcher func(error) bool
 	}{
 		{
-			name:                "case 0: valid /16 network",
-			inputCIDR:           "172.31.0.0/16",
-			expectedNetworkIP:   net.IPv4(172, 31, 0, 0),
-			expectedAPIServerIP: net.IPv4(172, 31, 0, 1),
-			errorMatcher:        nil,
+			name:         "case 0: IPv4 single-stack",
+			inputSubnet:  "10.2.0.0",
+			inputPrefix:  "16",
+			expectedCIDR: "10.2.0.0/16",
+			errorMatcher: nil,
+		},
+		{
+			name:         "case 1: IPv6 single-stack",
+			inputSubnet:  "fd00:10:2::",
+			inputPrefix:  "64",
+			expectedCIDR: "fd00:10:2::/64",
+			errorMatcher: nil,
+		},
+		{
+			name:         "case 2: dual-stack",
+			inputSubnet:  "10.2.0.0, fd00:10:2::",
+			inputPrefix:  "16, 64",
+			expectedCIDR: "10.2.0.0/16,fd00:10:2::/64",
+			errorMatcher: nil,
+		},
+		{
+			name:         "case 3: mismatching number of prefixes",
+			inputSubnet:  "10.2.0.0,fd00:10:2::",
+			inputPrefix:  "16",
+			expectedCIDR: "",
+			errorMatcher: IsInvalidConfig,
 		},
 		{
-			name:                "case 1: valid /24 network",
-			inputCIDR:           "192.168.12.0/24",
-			expectedNetworkIP:   net.IPv4(192, 168, 12, 0),
-			expectedAPIServerIP: net.IPv4(192, 168, 12, 1),
-			errorMatcher:        nil,
+			name:         "case 4: same IP family twice",
+			inputSubnet:  "10.2.0.0,10.3.0.0",
+			inputPrefix:  "16,16",
+			expectedCIDR: "",
+			errorMatcher: IsInvalidConfig,
 		},
 		{
-			name:                "case 2: valid /24 network",
-			inputCIDR:           "192.168.12.16/24",
-			expectedNetworkIP:   net.IPv4(192, 168, 12, 0),
-			expectedAPIServerIP: net.IPv4(192, 168, 12, 1),
-			errorMatcher:        nil,
+			name:         "case 5: invalid subnet",
+			inputSubnet:  "10.2.0",
+			inputPrefix:  "16",
+			expectedCIDR: "",
+			errorMatcher: IsInvalidConfig,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cidr, err := parseCalicoCIDR(tc.inputSubnet, tc.inputPrefix)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if cidr != tc.expectedCIDR {
+				t.Fatalf("CIDR == %q, want %q", cidr, tc.expectedCIDR)
+			}
+		})
+	}
+}
+
+func Test_parseKubeConfigProfiles(t *testing.T) {
+	testCases := []struct {
+		name             string
+		input            string
+		expectedProfiles []key.KubeConfigProfile
+		errorMatcher     func(error) bool
+	}{
+		{
+			name:             "case 0: no profiles",
+			input:            "",
+			expectedProfiles: nil,
+			errorMatcher:     nil,
 		},
 		{
-			name:                "case 3: invalid /25 network",
-			inputCIDR:           "172.31.0.0/25",
-			expectedNetworkIP:   nil,
-			expectedAPIServerIP: nil,
-			errorMatcher:        IsInvalidConfig,
+			name: "case 1: read-only and namespace scoped profiles",
+			input: `
+- name: read-only
+  clusterRole: view
+- name: monitoring
+  clusterRole: edit
+  namespaces:
+  - monitoring
+- name: support
+  groups:
+  - giantswarm:support
+`,
+			expectedProfiles: []key.KubeConfigProfile{
+				{
+					Name:        "read-only",
+					ClusterRole: "view",
+				},
+				{
+					Name:        "monitoring",
+					ClusterRole: "edit",
+					Namespaces:  []string{"monitoring"},
+				},
+				{
+					Name:   "support",
+					Groups: []string{"giantswarm:support"},
+				},
+			},
+			errorMatcher: nil,
 		},
 		{
-			name:                "case 4: invalid /27 network",
-			inputCIDR:           "172.31.0.0/27",
-			expectedNetworkIP:   nil,
-			expectedAPIServerIP: nil,
-			errorMatcher:        IsInvalidConfig,
+			name: "case 2: invalid profile name",
+			input: `
+- name: Read_Only
+  clusterRole: view
+`,
+			expectedProfiles: nil,
+			errorMatcher:     IsInvalidConfig,
 		},
 		{
-			name:                "case 5: invalid IPv6 network",
-			inputCIDR:           "2001:db8:a0b:12f0::1/32",
-			expectedNetworkIP:   nil,
-			expectedAPIServerIP: nil,
-			errorMatcher:        IsInvalidConfig,
+			name: "case 3: duplicated profile name",
+			input: `
+- name: read-only
+  clusterRole: view
+- name: read-only
+  clusterRole: edit
+`,
+			expectedProfiles: nil,
+			errorMatcher:     IsInvalidConfig,
 		},
 		{
-			name:                "case 6: invalid IPv4 network mask",
-			inputCIDR:           "172.0.0.1/33",
-			expectedNetworkIP:   nil,
-			expectedAPIServerIP: nil,
-			errorMatcher:        IsInvalidConfig,
+			name: "case 4: namespaces without cluster role",
+			input: `
+- name: monitoring
+  namespaces:
+  - monitoring
+`,
+			expectedProfiles: nil,
+			errorMatcher:     IsInvalidConfig,
 		},
 		{
-			name:                "case 6: invalid CIDR",
-			inputCIDR:           "256.0.0.1/33",
-			expectedNetworkIP:   nil,
-			expectedAPIServerIP: nil,
-			errorMatcher:        IsInvalidConfig,
+			name:             "case 5: invalid YAML",
+			input:            "name: read-only",
+			expectedProfiles: nil,
+			errorMatcher:     IsInvalidConfig,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			networkIP, apiServerIP, err := parseClusterIPRange(tc.inputCIDR)
+			profiles, err := parseKubeConfigProfiles(tc.input)
 
 			switch {
 			case err == nil && tc.errorMatcher == nil:
@@ -87,16 +178,207 @@ func Test_parseClusterIPRange(t *testing.T) {
 				t.Fatalf("error == %#v, want matching", err)
 			}
 
-			// Force IPs to same representation for comparison.
-			networkIP = networkIP.To4()
-			tc.expectedNetworkIP = tc.expectedNetworkIP.To4()
-			apiServerIP = apiServerIP.To4()
-			tc.expectedAPIServerIP = tc.expectedAPIServerIP.To4()
+			if tc.errorMatcher != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(profiles, tc.expectedProfiles) {
+				t.Fatalf("profiles == %#v, want %#v", profiles, tc.expectedProfiles)
+			}
+		})
+	}
+}
+
+func Test_parseDeletionPhaseTimeouts(t *testing.T) {
+	testCases := []struct {
+		name             string
+		input            string
+		expectedTimeouts map[string]time.Duration
+		errorMatcher     func(error) bool
+	}{
+		{
+			name:             "case 0: no timeouts",
+			input:            "",
+			expectedTimeouts: map[string]time.Duration{},
+			errorMatcher:     nil,
+		},
+		{
+			name: "case 1: timeouts of some phases",
+			input: `
+NodePools: 1h
+Management: 15m
+`,
+			expectedTimeouts: map[string]time.Duration{
+				"NodePools":  time.Hour,
+				"Management": 15 * time.Minute,
+			},
+			errorMatcher: nil,
+		},
+		{
+			name: "case 2: unknown phase",
+			input: `
+Workers: 1h
+`,
+			expectedTimeouts: nil,
+			errorMatcher:     IsInvalidConfig,
+		},
+		{
+			name: "case 3: invalid duration",
+			input: `
+NodePools: one hour
+`,
+			expectedTimeouts: nil,
+			errorMatcher:     IsInvalidConfig,
+		},
+		{
+			name: "case 4: non-positive duration",
+			input: `
+NodePools: 0s
+`,
+			expectedTimeouts: nil,
+			errorMatcher:     IsInvalidConfig,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			timeouts, err := parseDeletionPhaseTimeouts(tc.input)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if tc.errorMatcher != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(timeouts, tc.expectedTimeouts) {
+				t.Fatalf("timeouts == %#v, want %#v", timeouts, tc.expectedTimeouts)
+			}
+		})
+	}
+}
+
+func Test_parseHealthProbes(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          string
+		expectedProbes []key.HealthProbe
+		errorMatcher   func(error) bool
+	}{
+		{
+			name:           "case 0: no probes",
+			input:          "",
+			expectedProbes: nil,
+			errorMatcher:   nil,
+		},
+		{
+			name: "case 1: readyz, daemonset and deployment probes",
+			input: `
+- kind: readyz
+- kind: daemonset
+  name: calico-node
+- kind: deployment
+  name: coredns
+  namespace: kube-system
+`,
+			expectedProbes: []key.HealthProbe{
+				{
+					Kind: "readyz",
+				},
+				{
+					Kind: "daemonset",
+					Name: "calico-node",
+				},
+				{
+					Kind:      "deployment",
+					Name:      "coredns",
+					Namespace: "kube-system",
+				},
+			},
+			errorMatcher: nil,
+		},
+		{
+			name: "case 2: daemonset probe without name",
+			input: `
+- kind: daemonset
+`,
+			expectedProbes: nil,
+			errorMatcher:   IsInvalidConfig,
+		},
+		{
+			name: "case 3: unknown probe kind",
+			input: `
+- kind: statefulset
+  name: etcd
+`,
+			expectedProbes: nil,
+			errorMatcher:   IsInvalidConfig,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			probes, err := parseHealthProbes(tc.input)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if tc.errorMatcher != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(probes, tc.expectedProbes) {
+				t.Fatalf("probes == %#v, want %#v", probes, tc.expectedProbes)
+			}
+		})
+	}
+}
+
+func Test_parseRegistryMirrors(t *testing.T) {
+	testCases := []struct {
+		name            string
+		input           string
+		expectedMirrors []string
+	}{
+		{
+			name:            "case 0: no mirrors",
+			input:           "",
+			expectedMirrors: []string{},
+		},
+		{
+			name:            "case 1: single mirror",
+			input:           "giantswarm.azurecr.io",
+			expectedMirrors: []string{"giantswarm.azurecr.io"},
+		},
+		{
+			name:            "case 2: mirrors with whitespace and empty entries",
+			input:           " giantswarm.azurecr.io, ,mirror.example.com,",
+			expectedMirrors: []string{"giantswarm.azurecr.io", "mirror.example.com"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mirrors := parseRegistryMirrors(tc.input)
 
-			if !reflect.DeepEqual(networkIP, tc.expectedNetworkIP) ||
-				!reflect.DeepEqual(apiServerIP, tc.expectedAPIServerIP) {
-				t.Fatalf("NetworkIP == %q, want %q, APIServerIP == %q, want %q",
-					networkIP, tc.expectedNetworkIP, apiServerIP, tc.expectedAPIServerIP)
+			if !reflect.DeepEqual(mirrors, tc.expectedMirrors) {
+				t.Fatalf("mirrors == %#v, want %#v", mirrors, tc.expectedMirrors)
 			}
 		})
 	}